@@ -0,0 +1,203 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"cli-runner/config"
+)
+
+// API 키 권한 범위
+const (
+	ScopeRun   = "run"   // 프로세스 실행 및 세션 이어가기
+	ScopeRead  = "read"  // 상태, 결과, 스트림, 목록 조회
+	ScopeStop  = "stop"  // 프로세스 중지 및 삭제
+	ScopeAdmin = "admin" // 모든 권한, 다른 테넌트의 프로세스 접근
+)
+
+// Scopes는 지원하는 모든 권한 범위입니다
+var Scopes = []string{ScopeRun, ScopeRead, ScopeStop, ScopeAdmin}
+
+// principalKey는 인증된 키를 gin.Context에 보관하는 키입니다
+const principalKey = "principal"
+
+// Principal은 요청을 보낸 API 키입니다
+// 인증을 사용하지 않으면 nil이며, nil은 모든 권한을 가진 것으로 취급합니다
+type Principal struct {
+	Name   string
+	Scopes []string
+	Tenant string // 사용량 제한과 조회 범위의 단위 (설정하지 않으면 키 이름, 소문자로 정규화)
+}
+
+// Has는 키가 scope 권한을 가졌는지 여부를 반환합니다 (admin은 모든 권한 포함)
+func (p *Principal) Has(scope string) bool {
+	if p == nil {
+		return true
+	}
+	return slices.Contains(p.Scopes, scope) || slices.Contains(p.Scopes, ScopeAdmin)
+}
+
+// Owns는 키가 owner의 프로세스를 중지하거나 이어갈 수 있는지 여부를 반환합니다
+func (p *Principal) Owns(owner string) bool {
+	return p == nil || p.Name == owner || slices.Contains(p.Scopes, ScopeAdmin)
+}
+
+// CanView는 키가 tenant의 프로세스를 조회할 수 있는지 여부를 반환합니다 (같은 테넌트의 키끼리 공유)
+func (p *Principal) CanView(tenant string) bool {
+	return p == nil || p.Tenant == tenant || slices.Contains(p.Scopes, ScopeAdmin)
+}
+
+// owner는 새 프로세스에 기록할 소유자 이름을 반환합니다
+func (p *Principal) owner() string {
+	if p == nil {
+		return ""
+	}
+	return p.Name
+}
+
+// tenant는 새 프로세스에 기록할 테넌트를 반환합니다
+func (p *Principal) tenant() string {
+	if p == nil {
+		return ""
+	}
+	return p.Tenant
+}
+
+// tenantFilter는 기록 조회에 사용할 테넌트 조건을 반환합니다 (admin이면 전체)
+func (p *Principal) tenantFilter() string {
+	if p == nil || slices.Contains(p.Scopes, ScopeAdmin) {
+		return ""
+	}
+	return p.Tenant
+}
+
+// apiKey는 설정에서 읽은 키 해시와 권한입니다
+type apiKey struct {
+	principal Principal
+	hash      []byte
+}
+
+// Authenticator는 Bearer 토큰을 설정된 API 키 해시와 비교합니다
+type Authenticator struct {
+	keys []apiKey
+}
+
+// NewAuthenticator는 설정의 API 키를 검증하여 Authenticator를 생성합니다
+// 키가 하나도 없으면 인증하지 않는 Authenticator를 반환합니다
+func NewAuthenticator(cfg config.AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{}
+	names := make(map[string]bool)
+
+	for i, key := range cfg.Keys {
+		if key.Name == "" {
+			return nil, fmt.Errorf("auth.keys[%d]: name is required", i)
+		}
+		if names[key.Name] {
+			return nil, fmt.Errorf("auth.keys[%d]: duplicate name %q", i, key.Name)
+		}
+		names[key.Name] = true
+
+		hash, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(key.Hash), "sha256:"))
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("auth.keys[%d] (%s): hash must be a hex-encoded SHA-256 digest", i, key.Name)
+		}
+
+		if len(key.Scopes) == 0 {
+			return nil, fmt.Errorf("auth.keys[%d] (%s): at least one scope is required", i, key.Name)
+		}
+		for _, scope := range key.Scopes {
+			if !slices.Contains(Scopes, scope) {
+				return nil, fmt.Errorf("auth.keys[%d] (%s): unknown scope %q", i, key.Name, scope)
+			}
+		}
+
+		a.keys = append(a.keys, apiKey{
+			principal: Principal{Name: key.Name, Scopes: key.Scopes, Tenant: key.TenantName()},
+			hash:      hash,
+		})
+	}
+
+	return a, nil
+}
+
+// Enabled는 API 키가 하나 이상 설정되어 인증을 사용하는지 여부를 반환합니다
+func (a *Authenticator) Enabled() bool {
+	return len(a.keys) > 0
+}
+
+// Authenticate는 토큰에 해당하는 키를 반환합니다
+// 모든 키와 상수 시간으로 비교하므로 응답 시간으로 일치 여부를 알 수 없습니다
+func (a *Authenticator) Authenticate(token string) (*Principal, bool) {
+	sum := sha256.Sum256([]byte(token))
+
+	var found *Principal
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare(sum[:], a.keys[i].hash) == 1 {
+			found = &a.keys[i].principal
+		}
+	}
+	return found, found != nil
+}
+
+// authenticate는 Authorization: Bearer <key> 헤더로 요청한 키를 확인합니다
+// 헤더를 설정할 수 없는 EventSource와 브라우저 WebSocket은 access_token 쿼리 파라미터를 사용할 수 있습니다
+func (s *Server) authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !s.auth.Enabled() {
+			c.Next()
+			return
+		}
+
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok {
+			token = c.Query("access_token")
+		}
+
+		principal, ok := s.auth.Authenticate(strings.TrimSpace(token))
+		if token == "" || !ok {
+			s.logger.Warn().
+				Str("path", c.Request.URL.Path).
+				Str("ip", c.ClientIP()).
+				Bool("tokenPresent", token != "").
+				Msg("Unauthorized request")
+			c.Header("WWW-Authenticate", `Bearer realm="cli-runner"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or missing API key"})
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+// requireScope는 인증된 키가 scope 권한을 가졌는지 확인합니다
+func (s *Server) requireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := principalFrom(c)
+		if !principal.Has(scope) {
+			s.logger.Warn().
+				Str("path", c.Request.URL.Path).
+				Str("key", principal.Name).
+				Str("scope", scope).
+				Msg("Forbidden request")
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("API key lacks the '%s' scope", scope)})
+			return
+		}
+		c.Next()
+	}
+}
+
+// principalFrom은 요청의 인증된 키를 반환합니다 (인증을 사용하지 않으면 nil)
+func principalFrom(c *gin.Context) *Principal {
+	if value, ok := c.Get(principalKey); ok {
+		return value.(*Principal)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"cli-runner/config"
+)
+
+// testKey는 API 키 설정을 생성합니다 (토큰은 name-key)
+func testKey(name, tenant string, scopes ...string) config.APIKeyConfig {
+	sum := sha256.Sum256([]byte(name + "-key"))
+	return config.APIKeyConfig{Name: name, Hash: hex.EncodeToString(sum[:]), Scopes: scopes, Tenant: tenant}
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	valid := testKey("alice", "", ScopeRun)
+
+	tests := []struct {
+		name    string
+		keys    []config.APIKeyConfig
+		enabled bool
+		wantErr string
+	}{
+		{name: "no keys disables auth"},
+		{name: "valid key", keys: []config.APIKeyConfig{valid}, enabled: true},
+		{name: "sha256 prefix", keys: []config.APIKeyConfig{{Name: "alice", Hash: "SHA256:" + strings.ToUpper(valid.Hash), Scopes: []string{ScopeRun}}}, enabled: true},
+		{name: "missing name", keys: []config.APIKeyConfig{{Hash: valid.Hash, Scopes: []string{ScopeRun}}}, wantErr: "name is required"},
+		{name: "duplicate name", keys: []config.APIKeyConfig{valid, valid}, wantErr: "duplicate name"},
+		{name: "plain key instead of hash", keys: []config.APIKeyConfig{{Name: "alice", Hash: "alice-key", Scopes: []string{ScopeRun}}}, wantErr: "SHA-256"},
+		{name: "no scopes", keys: []config.APIKeyConfig{{Name: "alice", Hash: valid.Hash}}, wantErr: "at least one scope"},
+		{name: "unknown scope", keys: []config.APIKeyConfig{testKey("alice", "", "write")}, wantErr: `unknown scope "write"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := NewAuthenticator(config.AuthConfig{Keys: tt.keys})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if auth.Enabled() != tt.enabled {
+				t.Errorf("Enabled() = %v, want %v", auth.Enabled(), tt.enabled)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	cfg := testConfig()
+	cfg.Auth.Keys = []config.APIKeyConfig{
+		testKey("reader", "", ScopeRead),
+		testKey("runner", "", ScopeRun),
+		testKey("admin", "", ScopeAdmin),
+	}
+	s := newTestServer(t, cfg)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		header     []string
+		wantStatus int
+	}{
+		{name: "missing key", method: http.MethodGet, path: "/api/v1/connectors", wantStatus: http.StatusUnauthorized},
+		{name: "wrong key", method: http.MethodGet, path: "/api/v1/connectors", token: "wrong-key", wantStatus: http.StatusUnauthorized},
+		{name: "non-bearer scheme", method: http.MethodGet, path: "/api/v1/connectors", header: []string{"Authorization", "Basic cmVhZGVyLWtleQ=="}, wantStatus: http.StatusUnauthorized},
+		{name: "scope granted", method: http.MethodGet, path: "/api/v1/connectors", token: "reader-key", wantStatus: http.StatusOK},
+		{name: "access_token query", method: http.MethodGet, path: "/api/v1/connectors?access_token=reader-key", wantStatus: http.StatusOK},
+		{name: "scope missing", method: http.MethodGet, path: "/api/v1/connectors", token: "runner-key", wantStatus: http.StatusForbidden},
+		{name: "run scope missing", method: http.MethodPost, path: "/api/v1/run", token: "reader-key", wantStatus: http.StatusForbidden},
+		{name: "admin has every scope", method: http.MethodGet, path: "/api/v1/connectors", token: "admin-key", wantStatus: http.StatusOK},
+		{name: "admin-only route", method: http.MethodGet, path: "/api/v1/rate-limits", token: "reader-key", wantStatus: http.StatusForbidden},
+		{name: "health stays open", method: http.MethodGet, path: "/health", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(s, tt.method, tt.path, tt.token, "", tt.header...)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d %s, want %d", w.Code, w.Body, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 response without WWW-Authenticate")
+			}
+		})
+	}
+}
+
+// 같은 테넌트의 키는 서로의 프로세스를 조회만 할 수 있고, 다른 테넌트에게는 보이지 않음
+func TestProcessAccess(t *testing.T) {
+	cfg := testConfig()
+	cfg.Auth.Keys = []config.APIKeyConfig{
+		testKey("alice", "team", ScopeRun, ScopeRead, ScopeStop),
+		testKey("bob", "team", ScopeRun, ScopeRead, ScopeStop),
+		testKey("carol", "", ScopeRun, ScopeRead, ScopeStop),
+		testKey("admin", "", ScopeAdmin),
+	}
+	s := newTestServer(t, cfg)
+	id := run(t, s, "alice-key")
+
+	tests := []struct {
+		name       string
+		token      string
+		method     string
+		wantStatus int
+	}{
+		{name: "owner reads", token: "alice-key", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "same tenant reads", token: "bob-key", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "same tenant cannot delete", token: "bob-key", method: http.MethodDelete, wantStatus: http.StatusForbidden},
+		{name: "other tenant cannot see", token: "carol-key", method: http.MethodGet, wantStatus: http.StatusNotFound},
+		{name: "other tenant cannot delete", token: "carol-key", method: http.MethodDelete, wantStatus: http.StatusNotFound},
+		{name: "admin reads", token: "admin-key", method: http.MethodGet, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(s, tt.method, "/api/v1/process/"+id, tt.token, "")
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d %s, want %d", w.Code, w.Body, tt.wantStatus)
+			}
+		})
+	}
+
+	// 목록도 테넌트 범위로 제한
+	for token, want := range map[string]int{"bob-key": 1, "carol-key": 0, "admin-key": 1} {
+		w := request(s, http.MethodGet, "/api/v1/processes", token, "")
+		var response struct {
+			Processes []map[string]any
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+		if len(response.Processes) != want {
+			t.Errorf("%s lists %d processes, want %d", token, len(response.Processes), want)
+		}
+	}
+
+	// 소유자는 삭제 가능 (admin도 다른 키의 프로세스를 삭제할 수 있음)
+	if w := request(s, http.MethodDelete, "/api/v1/process/"+id, "alice-key", ""); w.Code != http.StatusOK {
+		t.Errorf("owner DELETE = %d %s, want 200", w.Code, w.Body)
+	}
+}
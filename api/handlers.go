@@ -2,47 +2,113 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
+	"cli-runner/config"
 	"cli-runner/connector"
+	"cli-runner/pkg/metrics"
 	"cli-runner/runner"
 )
 
+// SSE 스트림 data 형식
+const (
+	streamFormatRaw      = "raw"      // CLI 원본 JSON (기본값)
+	streamFormatEnvelope = "envelope" // type/data/normalized/timestamp를 포함한 전체 Event
+)
+
+// replayBatchSize는 스트림 재생 시 이벤트 로그에서 한 번에 읽는 이벤트 수입니다
+const replayBatchSize = 500
+
 // Handlers는 모든 HTTP 핸들러를 포함합니다
 type Handlers struct {
+	config   *config.Config
 	manager  *runner.Manager
 	runner   *runner.Runner
 	registry *connector.Registry
+	limiters map[string]*RateLimiter // 라우트 이름 → 속도 제한 (WebSocket 제어 메시지에 적용)
 	logger   zerolog.Logger
+	closing  chan struct{} // 서버 종료 시 닫힘 (WebSocket 세션 종료 신호)
+	once     sync.Once
 }
 
 // NewHandlers는 의존성과 함께 핸들러를 생성합니다
-func NewHandlers(manager *runner.Manager, runnerInstance *runner.Runner, registry *connector.Registry, logger zerolog.Logger) *Handlers {
+func NewHandlers(cfg *config.Config, manager *runner.Manager, runnerInstance *runner.Runner, registry *connector.Registry, limiters map[string]*RateLimiter, logger zerolog.Logger) *Handlers {
 	return &Handlers{
+		config:   cfg,
 		manager:  manager,
 		runner:   runnerInstance,
 		registry: registry,
+		limiters: limiters,
 		logger:   logger.With().Str("component", "handlers").Logger(),
+		closing:  make(chan struct{}),
 	}
 }
 
+// Shutdown은 열려 있는 WebSocket 세션에 서버 종료를 알립니다
+// 세션은 구독 중인 프로세스의 남은 이벤트를 모두 보낸 뒤 연결을 닫습니다
+func (h *Handlers) Shutdown() {
+	h.once.Do(func() {
+		close(h.closing)
+	})
+}
+
 // RunRequest는 POST /run 요청 바디를 나타냅니다
 type RunRequest struct {
-	Connector string `json:"connector" binding:"required" example:"claude"`
-	Prompt    string `json:"prompt" binding:"required" example:"Hello, how are you?"`
-	WorkDir   string `json:"workDir,omitempty" example:"/path/to/project"`
+	Connector string         `json:"connector" binding:"required" example:"claude"`
+	Prompt    string         `json:"prompt" binding:"required" example:"Hello, how are you?"`
+	WorkDir   string         `json:"workDir,omitempty" example:"/path/to/project"`
+	Options   runner.Options `json:"options,omitempty"`
+	Priority  string         `json:"priority,omitempty" binding:"omitempty,oneof=interactive batch" example:"interactive"` // 대기열 우선순위 (기본값: interactive)
+	TimeoutSettings
+}
+
+// TimeoutSettings는 요청별 타임아웃을 나타냅니다 (0이면 커넥터/전역 기본값 사용)
+type TimeoutSettings struct {
+	TimeoutSeconds     int `json:"timeoutSeconds,omitempty" binding:"min=0" example:"600"`
+	IdleTimeoutSeconds int `json:"idleTimeoutSeconds,omitempty" binding:"min=0" example:"120"` // 이벤트 없이 대기할 최대 시간
+}
+
+// timeouts는 초 단위 설정을 Duration으로 변환합니다
+func (t TimeoutSettings) timeouts() (time.Duration, time.Duration) {
+	return time.Duration(t.TimeoutSeconds) * time.Second, time.Duration(t.IdleTimeoutSeconds) * time.Second
+}
+
+// ContinueRequest는 POST /process/:id/continue 요청 바디를 나타냅니다
+type ContinueRequest struct {
+	Prompt   string         `json:"prompt" binding:"required" example:"Now add tests for it"`
+	WorkDir  string         `json:"workDir,omitempty" example:"/path/to/project"`
+	Options  runner.Options `json:"options,omitempty"`
+	Priority string         `json:"priority,omitempty" binding:"omitempty,oneof=interactive batch" example:"interactive"`
+	TimeoutSettings
+}
+
+// ContinueResponse는 POST /process/:id/continue의 응답을 나타냅니다
+type ContinueResponse struct {
+	ProcessID     string `json:"processId" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	ParentID      string `json:"parentId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SessionID     string `json:"sessionId" example:"7f3c9a2e-1b4d-4c8a-9e6f-2d5b8a1c3e7f"`
+	Status        string `json:"status,omitempty" example:"queued"`
+	QueuePosition int    `json:"queuePosition,omitempty" example:"3"`
+	WaitReason    string `json:"waitReason,omitempty" example:"global_limit"`
 }
 
 // RunResponse는 POST /run의 응답을 나타냅니다
 type RunResponse struct {
-	ProcessID string `json:"processId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProcessID     string `json:"processId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status        string `json:"status,omitempty" example:"queued"`   // 대기열에 추가된 경우에만 포함
+	QueuePosition int    `json:"queuePosition,omitempty" example:"3"` // 같은 우선순위 안에서 1부터 시작하는 대기 순번
+	WaitReason    string `json:"waitReason,omitempty" example:"global_limit"`
 }
 
 // ErrorResponse는 에러 응답을 나타냅니다
@@ -53,13 +119,23 @@ type ErrorResponse struct {
 
 // ProcessStatus는 프로세스의 상태를 나타냅니다
 type ProcessStatus struct {
-	ID          string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Connector   string  `json:"connector" example:"claude"`
-	Prompt      string  `json:"prompt" example:"Hello"`
-	WorkDir     string  `json:"workDir,omitempty" example:"/path/to/project"`
-	Status      string  `json:"status" example:"running"`
-	StartedAt   string  `json:"startedAt" example:"2024-01-01T12:00:00Z"`
-	CompletedAt *string `json:"completedAt,omitempty" example:"2024-01-01T12:01:00Z"`
+	ID                 string          `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Connector          string          `json:"connector" example:"claude"`
+	Prompt             string          `json:"prompt" example:"Hello"`
+	WorkDir            string          `json:"workDir,omitempty" example:"/path/to/project"`
+	Options            *runner.Options `json:"options,omitempty"`
+	ParentID           string          `json:"parentId,omitempty" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	SessionID          string          `json:"sessionId,omitempty" example:"7f3c9a2e-1b4d-4c8a-9e6f-2d5b8a1c3e7f"`
+	Owner              string          `json:"owner,omitempty" example:"ide-plugin"` // 실행한 API 키 이름 (인증 사용 시)
+	Tenant             string          `json:"tenant,omitempty" example:"platform"`  // 실행한 키의 테넌트 (인증 사용 시)
+	TimeoutSeconds     int             `json:"timeoutSeconds,omitempty" example:"1800"`
+	IdleTimeoutSeconds int             `json:"idleTimeoutSeconds,omitempty" example:"120"`
+	Status             string          `json:"status" example:"running"` // queued, pending, running, completed, failed, stopped, interrupted
+	Priority           string          `json:"priority" example:"interactive"`
+	QueuePosition      int             `json:"queuePosition,omitempty" example:"2"`            // queued 상태일 때 같은 우선순위 안에서 1부터 시작하는 대기 순번
+	WaitReason         string          `json:"waitReason,omitempty" example:"connector_limit"` // queued 상태일 때 대기 사유: global_limit, connector_limit, tenant_limit, priority
+	StartedAt          string          `json:"startedAt" example:"2024-01-01T12:00:00Z"`
+	CompletedAt        *string         `json:"completedAt,omitempty" example:"2024-01-01T12:01:00Z"`
 }
 
 // ProcessResult는 완료된 프로세스의 결과를 나타냅니다
@@ -67,6 +143,7 @@ type ProcessResult struct {
 	ExitCode int    `json:"exitCode" example:"0"`
 	Output   string `json:"output,omitempty"`
 	Error    string `json:"error,omitempty"`
+	Reason   string `json:"reason,omitempty" example:"idle_timeout"` // 강제 종료 사유: timeout, idle_timeout, user_stop
 }
 
 // ProcessListResponse는 프로세스 목록을 나타냅니다
@@ -75,10 +152,45 @@ type ProcessListResponse struct {
 	Count     int             `json:"count" example:"5"`
 }
 
+// HistoryQuery는 GET /history의 조회 조건을 나타냅니다
+type HistoryQuery struct {
+	Connector string `form:"connector"`
+	Status    string `form:"status"` // 쉼표로 구분된 상태 목록 (예: failed,interrupted)
+	Limit     int    `form:"limit,default=50" binding:"min=0,max=500"`
+	Offset    int    `form:"offset" binding:"min=0"`
+}
+
+// HistoryResponse는 저장된 실행 기록 목록을 나타냅니다
+type HistoryResponse struct {
+	Processes []ProcessStatus `json:"processes"`
+	Count     int             `json:"count" example:"20"`
+	Limit     int             `json:"limit" example:"50"`
+	Offset    int             `json:"offset" example:"0"`
+}
+
 // ConnectorListResponse는 커넥터 목록을 나타냅니다
 type ConnectorListResponse struct {
-	Connectors []string `json:"connectors" example:"claude,gemini"`
-	Count      int      `json:"count" example:"2"`
+	Connectors []string           `json:"connectors" example:"claude,gemini"`
+	Count      int                `json:"count" example:"2"`
+	Details    []connector.Status `json:"details"`
+}
+
+// TenantUsageResponse는 테넌트의 오늘 사용량과 제한을 나타냅니다
+type TenantUsageResponse struct {
+	Tenant         string       `json:"tenant" example:"platform"`
+	Day            string       `json:"day" example:"2024-01-01"` // 집계 날짜 (서버 로컬 시간, 자정에 초기화)
+	Active         int          `json:"active" example:"2"`       // 실행 슬롯을 차지한 (pending, running) 프로세스 수
+	Queued         int          `json:"queued" example:"1"`
+	Runs           int          `json:"runs" example:"37"`             // 오늘 생성된 프로세스 수
+	RuntimeSeconds int          `json:"runtimeSeconds" example:"5230"` // 오늘의 실행 시간 합계 (실행 중인 프로세스 포함)
+	Limits         TenantLimits `json:"limits"`
+}
+
+// TenantLimits는 테넌트에 적용되는 제한입니다 (0이면 제한 없음)
+type TenantLimits struct {
+	MaxConcurrent          int `json:"maxConcurrent" example:"3"`
+	MaxDailyRuns           int `json:"maxDailyRuns" example:"200"`
+	MaxDailyRuntimeSeconds int `json:"maxDailyRuntimeSeconds" example:"36000"`
 }
 
 // MessageResponse는 간단한 메시지 응답을 나타냅니다
@@ -94,9 +206,13 @@ type MessageResponse struct {
 // @Produce json
 // @Param request body RunRequest true "실행 요청"
 // @Success 202 {object} RunResponse "프로세스가 생성됨"
-// @Failure 400 {object} ErrorResponse "잘못된 요청"
-// @Failure 429 {object} ErrorResponse "최대 동시 실행 수 초과"
+// @Failure 400 {object} ErrorResponse "잘못된 요청 (options.resume 포함)"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한, 최대 동시 실행 수 초과, 대기열 가득 참 또는 테넌트 일일 사용량 초과"
 // @Failure 500 {object} ErrorResponse "서버 오류"
+// @Failure 503 {object} ErrorResponse "서버 종료 중"
+// @Security BearerAuth
 // @Router /run [post]
 func (h *Handlers) RunHandler(c *gin.Context) {
 	var req RunRequest
@@ -106,64 +222,321 @@ func (h *Handlers) RunHandler(c *gin.Context) {
 		return
 	}
 
+	// 세션은 소유권을 확인하는 continue로만 이어갈 수 있음
+	if req.Options.Resume != "" {
+		h.logger.Warn().Msg("Resume option on run request")
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": "options.resume is not allowed; use POST /process/{id}/continue",
+		})
+		return
+	}
+
+	timeout, idleTimeout := req.timeouts()
+	process := h.startProcess(c, runner.Spec{
+		Connector:   req.Connector,
+		Prompt:      req.Prompt,
+		WorkDir:     req.WorkDir,
+		Options:     req.Options,
+		Priority:    req.Priority,
+		Owner:       principalFrom(c).owner(),
+		Tenant:      principalFrom(c).tenant(),
+		Timeout:     timeout,
+		IdleTimeout: idleTimeout,
+	})
+	if process == nil {
+		return
+	}
+
+	// 즉시 processId 반환
+	c.JSON(http.StatusAccepted, h.acceptedResponse(process))
+}
+
+// ContinueHandler handles POST /api/v1/process/:id/continue
+// @Summary 이전 대화 이어가기
+// @Description 이전 프로세스의 CLI 세션을 resume하여 새로운 프로세스를 실행합니다
+// @Tags process
+// @Accept json
+// @Produce json
+// @Param id path string true "이전 프로세스 ID"
+// @Param request body ContinueRequest true "이어가기 요청"
+// @Success 202 {object} ContinueResponse "프로세스가 생성됨"
+// @Failure 400 {object} ErrorResponse "잘못된 요청 또는 resume을 지원하지 않는 커넥터"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없거나 다른 키가 실행한 프로세스"
+// @Failure 404 {object} ErrorResponse "프로세스를 찾을 수 없음"
+// @Failure 409 {object} ErrorResponse "이전 프로세스가 실행 중이거나 세션 ID가 없음"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한, 최대 동시 실행 수 초과, 대기열 가득 참 또는 테넌트 일일 사용량 초과"
+// @Failure 500 {object} ErrorResponse "서버 오류"
+// @Failure 503 {object} ErrorResponse "서버 종료 중"
+// @Security BearerAuth
+// @Router /process/{id}/continue [post]
+func (h *Handlers) ContinueHandler(c *gin.Context) {
+	parentID := c.Param("id")
+
+	var req ContinueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn().Err(err).Msg("Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	spec, reqErr := h.continueSpec(principalFrom(c), parentID, req)
+	if reqErr != nil {
+		c.JSON(reqErr.status, reqErr.body)
+		return
+	}
+
+	process := h.startProcess(c, spec)
+	if process == nil {
+		return
+	}
+
+	response := h.acceptedResponse(process)
+	response["parentId"] = spec.ParentID
+	response["sessionId"] = spec.Options.Resume
+	c.JSON(http.StatusAccepted, response)
+}
+
+// continueSpec은 이전 프로세스의 세션을 이어가는 실행 명세를 만듭니다
+// 자신이 실행한 프로세스만 이어갈 수 있으며 새 프로세스는 요청한 키의 소유가 됩니다
+func (h *Handlers) continueSpec(principal *Principal, parentID string, req ContinueRequest) (runner.Spec, *requestError) {
+	// 이전 프로세스 가져오기
+	parent, reqErr := h.ownProcess(principal, parentID)
+	if reqErr != nil {
+		return runner.Spec{}, reqErr
+	}
+
+	// 실행 중인 세션은 동시에 이어갈 수 없음
+	if parent.IsActive() {
+		return runner.Spec{}, newRequestError(http.StatusConflict, "Process is still running")
+	}
+
+	sessionID := parent.GetSessionID()
+	if sessionID == "" {
+		h.logger.Warn().
+			Str("processId", parentID).
+			Msg("No session ID to continue")
+		return runner.Spec{}, newRequestError(http.StatusConflict, "Process has no session to continue")
+	}
+
+	// 작업 디렉토리를 지정하지 않으면 이전 프로세스의 디렉토리 사용
+	workDir := req.WorkDir
+	if workDir == "" {
+		workDir = parent.WorkDir
+	}
+
+	options := req.Options
+	options.Resume = sessionID
+
+	timeout, idleTimeout := req.timeouts()
+	return runner.Spec{
+		Connector:   parent.Connector,
+		Prompt:      req.Prompt,
+		WorkDir:     workDir,
+		Options:     options,
+		ParentID:    parent.ID,
+		Priority:    req.Priority,
+		Owner:       principal.owner(),
+		Tenant:      principal.tenant(),
+		Timeout:     timeout,
+		IdleTimeout: idleTimeout,
+	}, nil
+}
+
+// requestError는 요청 처리 실패 시의 HTTP 상태 코드와 에러 응답 바디입니다
+// REST 핸들러와 WebSocket 제어 메시지가 같은 검증 로직을 공유할 때 사용합니다
+type requestError struct {
+	status int
+	body   gin.H
+}
+
+// newRequestError는 에러 메시지만 포함한 requestError를 생성합니다
+func newRequestError(status int, message string) *requestError {
+	return &requestError{status: status, body: gin.H{"error": message}}
+}
+
+// Error는 에러 응답의 메시지를 반환합니다 (details가 있으면 함께 포함)
+func (e *requestError) Error() string {
+	if details, ok := e.body["details"]; ok {
+		return fmt.Sprintf("%v: %v", e.body["error"], details)
+	}
+	return fmt.Sprint(e.body["error"])
+}
+
+// startProcess는 커넥터를 확인하고 프로세스를 생성하여 실행합니다
+// 실패하면 에러 응답을 작성하고 nil을 반환합니다
+func (h *Handlers) startProcess(c *gin.Context, spec runner.Spec) *runner.Process {
+	process, reqErr := h.createProcess(spec)
+	if reqErr != nil {
+		c.JSON(reqErr.status, reqErr.body)
+		return nil
+	}
+	return process
+}
+
+// createProcess는 커넥터와 옵션을 검증하고 매니저를 통해 프로세스를 생성합니다
+func (h *Handlers) createProcess(spec runner.Spec) (*runner.Process, *requestError) {
 	// 레지스트리에서 커넥터 가져오기
-	conn, err := h.registry.Get(req.Connector)
+	conn, err := h.registry.Get(spec.Connector)
 	if err != nil {
 		h.logger.Warn().
-			Str("connector", req.Connector).
+			Str("connector", spec.Connector).
 			Err(err).
 			Msg("Connector not found or unavailable")
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Connector '%s' not found or unavailable", req.Connector)})
-		return
+		return nil, newRequestError(http.StatusBadRequest, fmt.Sprintf("Connector '%s' not found or unavailable", spec.Connector))
 	}
 
-	// 매니저를 통해 프로세스 생성
-	process, err := h.manager.Create(req.Connector, req.Prompt, req.WorkDir)
-	if err != nil {
-		if err == runner.ErrMaxConcurrent {
-			h.logger.Warn().Msg("Max concurrent processes reached")
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Maximum concurrent processes reached"})
-			return
+	// 커넥터가 요청 옵션을 지원하는지 확인
+	if err := connector.ValidateOptions(conn, spec.Options); err != nil {
+		h.logger.Warn().
+			Str("connector", spec.Connector).
+			Err(err).
+			Msg("Invalid connector options")
+		return nil, &requestError{
+			status: http.StatusBadRequest,
+			body:   gin.H{"error": "Invalid options", "details": err.Error()},
 		}
-		h.logger.Error().Err(err).Msg("Failed to create process")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create process"})
-		return
 	}
 
-	// 러너를 통해 실행 생성 (30분 타임아웃)
-	timeout := 30 * time.Minute
-	if err := h.runner.Spawn(process, conn, timeout); err != nil {
-		h.logger.Error().
-			Str("processId", process.ID).
-			Err(err).
-			Msg("Failed to spawn process")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to spawn process"})
-		return
+	// 매니저를 통해 프로세스 생성 (실행 슬롯이 없으면 대기열에 추가되고 러너가 차례대로 시작)
+	process, err := h.manager.Create(spec, conn)
+	if err != nil {
+		switch {
+		case errors.Is(err, runner.ErrMaxConcurrent):
+			h.logger.Warn().Msg("Max concurrent processes reached")
+			return nil, newRequestError(http.StatusTooManyRequests, "Maximum concurrent processes reached")
+		case errors.Is(err, runner.ErrQueueFull):
+			h.logger.Warn().Msg("Process queue is full")
+			return nil, newRequestError(http.StatusTooManyRequests, "Process queue is full")
+		case errors.Is(err, runner.ErrTenantQuota):
+			h.logger.Warn().Str("tenant", spec.Tenant).Msg("Tenant quota exceeded")
+			return nil, &requestError{
+				status: http.StatusTooManyRequests,
+				body:   gin.H{"error": "Tenant quota exceeded", "details": err.Error()},
+			}
+		case errors.Is(err, runner.ErrShuttingDown):
+			h.logger.Warn().Msg("Rejected process while shutting down")
+			return nil, newRequestError(http.StatusServiceUnavailable, "Server is shutting down")
+		default:
+			h.logger.Error().Err(err).Msg("Failed to create process")
+			return nil, newRequestError(http.StatusInternalServerError, "Failed to create process")
+		}
 	}
 
 	h.logger.Info().
 		Str("processId", process.ID).
-		Str("connector", req.Connector).
-		Msg("Process spawned successfully")
+		Str("connector", spec.Connector).
+		Str("parentId", spec.ParentID).
+		Int("queuePosition", h.manager.QueuePosition(process.ID)).
+		Msg("Process accepted")
 
-	// 즉시 processId 반환
-	c.JSON(http.StatusAccepted, gin.H{"processId": process.ID})
+	return process, nil
+}
+
+// getProcess는 요청한 키가 조회할 수 있는 프로세스를 반환합니다
+// 다른 테넌트의 프로세스는 존재 여부를 드러내지 않도록 찾을 수 없는 것으로 처리합니다
+func (h *Handlers) getProcess(principal *Principal, id string) (*runner.Process, error) {
+	process, err := h.manager.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !principal.CanView(process.Tenant) {
+		return nil, runner.ErrProcessNotFound
+	}
+	return process, nil
+}
+
+// ownProcess는 요청한 키가 중지하거나 이어갈 수 있는 프로세스를 반환합니다
+// 같은 테넌트의 다른 키가 실행한 프로세스는 조회만 가능하므로 403을 반환합니다
+func (h *Handlers) ownProcess(principal *Principal, id string) (*runner.Process, *requestError) {
+	process, err := h.getProcess(principal, id)
+	if err != nil {
+		h.logger.Warn().
+			Str("processId", id).
+			Msg("Process not found")
+		return nil, newRequestError(http.StatusNotFound, "Process not found")
+	}
+	if !principal.Owns(process.Owner) {
+		h.logger.Warn().
+			Str("processId", id).
+			Str("key", principal.Name).
+			Str("owner", process.Owner).
+			Msg("Process belongs to another API key")
+		return nil, newRequestError(http.StatusForbidden, "Process belongs to another API key")
+	}
+	return process, nil
+}
+
+// processStatus는 프로세스 상태에 대기열 순번과 대기 사유를 추가합니다
+func (h *Handlers) processStatus(process *runner.Process) map[string]interface{} {
+	status := process.GetStatus()
+	if position := h.manager.QueuePosition(process.ID); position > 0 {
+		status["queuePosition"] = position
+		status["waitReason"] = h.manager.WaitReason(process.ID)
+	}
+	return status
+}
+
+// acceptedResponse는 생성된 프로세스의 202 응답 바디를 구성합니다
+// 대기열에 추가된 경우 queued 상태와 순번을 포함합니다
+func (h *Handlers) acceptedResponse(process *runner.Process) gin.H {
+	response := gin.H{"processId": process.ID}
+	if position := h.manager.QueuePosition(process.ID); position > 0 {
+		response["status"] = runner.StatusQueued
+		response["queuePosition"] = position
+		response["waitReason"] = h.manager.WaitReason(process.ID)
+	}
+	return response
 }
 
 // StreamHandler handles GET /api/v1/stream/:id
 // @Summary SSE 스트림 구독
-// @Description 프로세스의 실시간 이벤트를 SSE로 스트리밍합니다
+// @Description 프로세스의 이벤트를 offset부터 재생한 뒤 실시간 이벤트를 SSE로 스트리밍합니다
+// @Description 이벤트 로그가 설정되어 있으면 실행 길이와 무관하게 처음부터 재생할 수 있습니다
+// @Description 이벤트가 없어도 stream.heartbeatInterval마다 ": ping" 주석을 보내 프록시가 유휴 연결을 끊지 않도록 합니다
 // @Tags stream
 // @Produce text/event-stream
 // @Param id path string true "프로세스 ID"
+// @Param format query string false "data 형식 (raw: CLI 원본 JSON, envelope: 원본과 정규화된 이벤트를 포함한 전체 Event)" Enums(raw, envelope) default(raw)
+// @Param offset query int false "재생을 시작할 이벤트 위치 (0부터 시작)" default(0)
+// @Param since query int false "마지막으로 받은 이벤트 id, 이후 이벤트부터 전송 (offset보다 우선)"
+// @Param Last-Event-ID header int false "재연결 시 브라우저가 보내는 마지막 이벤트 id (since보다 우선)"
+// @Param backpressure query string false "클라이언트가 느려 구독 버퍼가 가득 찼을 때의 처리 (기본값: stream.backpressure)" Enums(block, drop, disconnect)
 // @Success 200 {string} string "SSE 이벤트 스트림"
+// @Failure 400 {object} ErrorResponse "잘못된 format, offset, since 또는 backpressure"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
 // @Failure 404 {object} ErrorResponse "프로세스를 찾을 수 없음"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한 초과 (rateLimit.routes)"
+// @Security BearerAuth
 // @Router /stream/{id} [get]
 func (h *Handlers) StreamHandler(c *gin.Context) {
 	processID := c.Param("id")
 
+	format := c.DefaultQuery("format", streamFormatRaw)
+	if format != streamFormatRaw && format != streamFormatEnvelope {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format: must be raw or envelope"})
+		return
+	}
+
+	offset, err := resumeOffset(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid resume position",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	backpressure := c.DefaultQuery("backpressure", h.config.Stream.Backpressure)
+	if !slices.Contains(runner.BackpressurePolicies, backpressure) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid backpressure: must be block, drop or disconnect"})
+		return
+	}
+
 	// 프로세스 가져오기
-	process, err := h.manager.Get(processID)
+	process, err := h.getProcess(principalFrom(c), processID)
 	if err != nil {
 		h.logger.Warn().
 			Str("processId", processID).
@@ -172,35 +545,72 @@ func (h *Handlers) StreamHandler(c *gin.Context) {
 		return
 	}
 
-	// SSE 헤더 설정
-	c.Header("Content-Type", "text/event-stream")
-	c.Header("Cache-Control", "no-cache")
-	c.Header("Connection", "keep-alive")
-	c.Header("Transfer-Encoding", "chunked")
+	// 스트림은 프로세스가 끝날 때까지 열려 있으므로 server.writeTimeout을 적용하지 않음
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug().Err(err).Msg("Failed to clear write deadline")
+	}
+
+	// SSE 헤더 설정 (프록시 버퍼링 비활성화 포함)
+	writer := NewSSEWriter(c, format)
+	writer.SetHeaders()
 
 	h.logger.Info().
 		Str("processId", processID).
 		Msg("SSE stream started")
 
-	// 먼저 버퍼된 이벤트 전송
-	bufferedEvents := process.GetEvents()
-	for _, event := range bufferedEvents {
-		h.writeSSEEvent(c.Writer, event)
-		c.Writer.Flush()
+	// 연결이 끊겼을 때 EventSource가 다시 연결하기까지 기다릴 시간
+	if retry := h.config.Stream.Retry; retry > 0 {
+		if err := writer.WriteRetry(retry); err != nil {
+			return
+		}
+	}
+
+	// 실시간 이벤트를 먼저 구독한 뒤 구독 시점까지의 이벤트를 offset부터 재생
+	// (구독과 스냅샷이 원자적이므로 그 사이의 이벤트가 누락되거나 중복되지 않음)
+	sub := process.Subscribe(uuid.New().String(), runner.SubscribeOptions{
+		Backpressure: backpressure,
+		BlockTimeout: h.config.Stream.BlockTimeout,
+		BufferSize:   h.config.Stream.SubscriberBuffer,
+	})
+	defer sub.Close()
+
+	subscribers := metrics.Subscribers.WithLabelValues(transportSSE)
+	subscribers.Inc()
+	defer subscribers.Dec()
+
+	err = replayEvents(process, offset, sub.Next, writer.WriteEvents)
+	if err != nil {
+		h.logger.Warn().
+			Str("processId", processID).
+			Int("offset", offset).
+			Err(err).
+			Msg("Failed to replay events")
+		return
 	}
 
-	// 실시간 이벤트 구독
-	subscriberID := uuid.New().String()
-	eventChan, cleanup := process.Subscribe(subscriberID)
-	defer cleanup()
+	// 에이전트가 오래 생각하는 동안에도 프록시가 연결을 끊지 않도록 주기적으로 ping 전송
+	var heartbeat <-chan time.Time
+	if interval := h.config.Stream.HeartbeatInterval; interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
 
 	// 완료되거나 클라이언트가 연결을 끊을 때까지 실시간 이벤트 스트리밍
 	clientClosed := c.Request.Context().Done()
 
 	for {
 		select {
-		case event, ok := <-eventChan:
+		case event, ok := <-sub.Events:
 			if !ok {
+				if sub.Disconnected() {
+					// disconnect 정책: 클라이언트는 Last-Event-ID로 다시 연결하여 이어서 받음
+					h.logger.Warn().
+						Str("processId", processID).
+						Msg("Slow subscriber disconnected from SSE stream")
+					return
+				}
+
 				// 채널이 닫힘, 프로세스 완료
 				h.logger.Info().
 					Str("processId", processID).
@@ -209,14 +619,13 @@ func (h *Handlers) StreamHandler(c *gin.Context) {
 			}
 
 			// 응답에 이벤트 작성
-			if err := h.writeSSEEvent(c.Writer, event); err != nil {
+			if err := writer.WriteEvent(event); err != nil {
 				h.logger.Warn().
 					Str("processId", processID).
 					Err(err).
 					Msg("Failed to write SSE event")
 				return
 			}
-			c.Writer.Flush()
 
 			// done 이벤트인 경우 스트림 닫기
 			if event.Type == "done" {
@@ -226,6 +635,15 @@ func (h *Handlers) StreamHandler(c *gin.Context) {
 				return
 			}
 
+		case <-heartbeat:
+			if err := writer.WritePing(); err != nil {
+				h.logger.Warn().
+					Str("processId", processID).
+					Err(err).
+					Msg("Failed to write SSE heartbeat")
+				return
+			}
+
 		case <-clientClosed:
 			// 클라이언트 연결 끊김
 			h.logger.Info().
@@ -243,13 +661,17 @@ func (h *Handlers) StreamHandler(c *gin.Context) {
 // @Produce json
 // @Param id path string true "프로세스 ID"
 // @Success 200 {object} ProcessStatus "프로세스 상태"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
 // @Failure 404 {object} ErrorResponse "프로세스를 찾을 수 없음"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한 초과 (rateLimit.routes)"
+// @Security BearerAuth
 // @Router /process/{id} [get]
 func (h *Handlers) GetProcessHandler(c *gin.Context) {
 	processID := c.Param("id")
 
 	// 프로세스 가져오기
-	process, err := h.manager.Get(processID)
+	process, err := h.getProcess(principalFrom(c), processID)
 	if err != nil {
 		h.logger.Warn().
 			Str("processId", processID).
@@ -258,8 +680,8 @@ func (h *Handlers) GetProcessHandler(c *gin.Context) {
 		return
 	}
 
-	// 프로세스 상태 반환
-	c.JSON(http.StatusOK, process.GetStatus())
+	// 프로세스 상태 반환 (대기 중이면 대기열 정보 포함)
+	c.JSON(http.StatusOK, h.processStatus(process))
 }
 
 // GetResultHandler handles GET /api/v1/result/:id
@@ -270,13 +692,17 @@ func (h *Handlers) GetProcessHandler(c *gin.Context) {
 // @Param id path string true "프로세스 ID"
 // @Success 200 {object} ProcessResult "프로세스 결과"
 // @Success 202 {object} map[string]string "프로세스 실행 중"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
 // @Failure 404 {object} ErrorResponse "프로세스를 찾을 수 없음"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한 초과 (rateLimit.routes)"
+// @Security BearerAuth
 // @Router /result/{id} [get]
 func (h *Handlers) GetResultHandler(c *gin.Context) {
 	processID := c.Param("id")
 
 	// 프로세스 가져오기
-	process, err := h.manager.Get(processID)
+	process, err := h.getProcess(principalFrom(c), processID)
 	if err != nil {
 		h.logger.Warn().
 			Str("processId", processID).
@@ -293,7 +719,7 @@ func (h *Handlers) GetResultHandler(c *gin.Context) {
 			Str("processId", processID).
 			Msg("Process not yet completed")
 		c.JSON(http.StatusAccepted, gin.H{
-			"status":  process.Status,
+			"status":  process.CurrentStatus(),
 			"message": "Process is still running",
 		})
 		return
@@ -311,12 +737,20 @@ func (h *Handlers) GetResultHandler(c *gin.Context) {
 // @Param id path string true "프로세스 ID"
 // @Success 200 {object} MessageResponse "삭제 성공"
 // @Failure 400 {object} ErrorResponse "삭제 실패"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없거나 다른 키가 실행한 프로세스"
 // @Failure 404 {object} ErrorResponse "프로세스를 찾을 수 없음"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한 초과 (rateLimit.routes)"
+// @Security BearerAuth
 // @Router /process/{id} [delete]
 func (h *Handlers) DeleteProcessHandler(c *gin.Context) {
 	processID := c.Param("id")
 
-	// 프로세스 중지
+	// 프로세스 중지 (자신이 실행한 프로세스만, admin은 전체)
+	if _, reqErr := h.ownProcess(principalFrom(c), processID); reqErr != nil {
+		c.JSON(reqErr.status, reqErr.body)
+		return
+	}
 	if err := h.manager.Stop(processID); err != nil {
 		h.logger.Warn().
 			Str("processId", processID).
@@ -345,18 +779,28 @@ func (h *Handlers) DeleteProcessHandler(c *gin.Context) {
 
 // ListProcessesHandler handles GET /api/v1/processes
 // @Summary 프로세스 목록 조회
-// @Description 모든 프로세스의 목록을 조회합니다
+// @Description 요청한 키와 같은 테넌트의 프로세스 목록을 조회합니다 (admin은 전체, tenant로 특정 테넌트만 조회)
 // @Tags process
 // @Produce json
+// @Param tenant query string false "테넌트 이름 (admin이 아니면 자신의 테넌트만 가능)"
 // @Success 200 {object} ProcessListResponse "프로세스 목록"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한 초과 (rateLimit.routes)"
+// @Security BearerAuth
 // @Router /processes [get]
 func (h *Handlers) ListProcessesHandler(c *gin.Context) {
+	principal := principalFrom(c)
+	tenant := strings.ToLower(c.Query("tenant"))
 	processes := h.manager.List()
 
-	// 상태 객체로 변환
+	// 상태 객체로 변환 (같은 테넌트의 프로세스만, admin은 전체)
 	result := make([]map[string]interface{}, 0, len(processes))
 	for _, process := range processes {
-		result = append(result, process.GetStatus())
+		if !principal.CanView(process.Tenant) || (tenant != "" && process.Tenant != tenant) {
+			continue
+		}
+		result = append(result, h.processStatus(process))
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -365,12 +809,117 @@ func (h *Handlers) ListProcessesHandler(c *gin.Context) {
 	})
 }
 
+// HistoryHandler handles GET /api/v1/history
+// @Summary 실행 기록 조회
+// @Description 저장소에 보관된 실행 기록을 최근 시작 순으로 조회합니다 (메모리에서 정리되었거나 서버 재시작 이전의 프로세스 포함)
+// @Tags process
+// @Produce json
+// @Param connector query string false "커넥터 이름"
+// @Param status query string false "쉼표로 구분된 상태 (예: failed,interrupted)"
+// @Param limit query int false "최대 개수 (기본값 50, 최대 500)"
+// @Param offset query int false "건너뛸 개수"
+// @Success 200 {object} HistoryResponse "실행 기록"
+// @Failure 400 {object} ErrorResponse "잘못된 조회 조건"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한 초과 (rateLimit.routes)"
+// @Failure 500 {object} ErrorResponse "저장소 조회 실패"
+// @Security BearerAuth
+// @Router /history [get]
+func (h *Handlers) HistoryHandler(c *gin.Context) {
+	var query HistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	filter := runner.ProcessFilter{
+		Connector: query.Connector,
+		Tenant:    principalFrom(c).tenantFilter(),
+		Limit:     query.Limit,
+		Offset:    query.Offset,
+	}
+	if query.Status != "" {
+		filter.Statuses = strings.Split(query.Status, ",")
+	}
+
+	records, err := h.manager.History(filter)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Msg("Failed to query process history")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query history"})
+		return
+	}
+
+	result := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		result = append(result, record.Summary())
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"processes": result,
+		"count":     len(result),
+		"limit":     query.Limit,
+		"offset":    query.Offset,
+	})
+}
+
+// TenantUsageHandler handles GET /api/v1/tenants/:id/usage
+// @Summary 테넌트 사용량 조회
+// @Description 테넌트의 동시 실행 수, 오늘의 실행 수와 실행 시간, 적용 중인 제한을 조회합니다 (admin이 아니면 자신의 테넌트만)
+// @Tags tenant
+// @Produce json
+// @Param id path string true "테넌트 이름 (대소문자 구분 없음)"
+// @Success 200 {object} TenantUsageResponse "테넌트 사용량"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
+// @Failure 404 {object} ErrorResponse "API 키, 사용량 제한 설정, 사용 기록이 모두 없는 테넌트이거나 다른 테넌트"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한 초과 (rateLimit.routes)"
+// @Security BearerAuth
+// @Router /tenants/{id}/usage [get]
+func (h *Handlers) TenantUsageHandler(c *gin.Context) {
+	tenant := strings.ToLower(c.Param("id"))
+
+	// 다른 테넌트는 존재 여부를 드러내지 않도록 찾을 수 없는 것으로 처리
+	if !principalFrom(c).CanView(tenant) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
+		return
+	}
+
+	usage, ok := h.manager.TenantUsage(tenant)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
+		return
+	}
+	c.JSON(http.StatusOK, TenantUsageResponse{
+		Tenant:         usage.Tenant,
+		Day:            usage.Day,
+		Active:         usage.Active,
+		Queued:         usage.Queued,
+		Runs:           usage.Runs,
+		RuntimeSeconds: int(usage.Runtime.Seconds()),
+		Limits: TenantLimits{
+			MaxConcurrent:          usage.Quota.MaxConcurrent,
+			MaxDailyRuns:           usage.Quota.MaxDailyRuns,
+			MaxDailyRuntimeSeconds: int(usage.Quota.MaxDailyRuntime.Seconds()),
+		},
+	})
+}
+
 // ListConnectorsHandler handles GET /api/v1/connectors
 // @Summary 사용 가능한 커넥터 목록
-// @Description 사용 가능한 AI CLI 커넥터 목록을 조회합니다
+// @Description 사용 가능한 AI CLI 커넥터 목록과 커넥터별 가용성 검사 결과(버전, 경로, 실패 사유)를 조회합니다
 // @Tags connector
 // @Produce json
 // @Success 200 {object} ConnectorListResponse "커넥터 목록"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한 초과 (rateLimit.routes)"
+// @Security BearerAuth
 // @Router /connectors [get]
 func (h *Handlers) ListConnectorsHandler(c *gin.Context) {
 	connectors := h.registry.Available()
@@ -378,6 +927,7 @@ func (h *Handlers) ListConnectorsHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"connectors": connectors,
 		"count":      len(connectors),
+		"details":    h.registry.Statuses(),
 	})
 }
 
@@ -388,13 +938,17 @@ func (h *Handlers) ListConnectorsHandler(c *gin.Context) {
 // @Produce json
 // @Param id path string true "프로세스 ID"
 // @Success 200 {object} map[string]interface{} "Result 데이터"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
 // @Failure 404 {object} ErrorResponse "프로세스를 찾을 수 없거나 데이터가 만료됨"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한 초과 (rateLimit.routes)"
+// @Security BearerAuth
 // @Router /result-data/{id} [get]
 func (h *Handlers) GetResultDataHandler(c *gin.Context) {
 	processID := c.Param("id")
 
 	// 프로세스 가져오기
-	process, err := h.manager.Get(processID)
+	process, err := h.getProcess(principalFrom(c), processID)
 	if err != nil {
 		h.logger.Warn().
 			Str("processId", processID).
@@ -435,10 +989,53 @@ func (h *Handlers) GetResultDataHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, resultJSON)
 }
 
+// replayEvents는 offset부터 until 이전까지의 이벤트를 배치 단위로 emit에 전달합니다
+// 이벤트 로그 없이 버퍼에서 밀려나 읽을 수 없는 범위는 gap 이벤트로 알립니다
+func replayEvents(process *runner.Process, offset, until int, emit func([]runner.Event) error) error {
+	for offset < until {
+		events, next, err := process.EventsFrom(offset, min(replayBatchSize, until-offset))
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		if first := events[0].Seq; first > offset+1 {
+			events = append([]runner.Event{runner.GapEvent(offset+1, first-1)}, events...)
+		}
+
+		if err := emit(events); err != nil {
+			return err
+		}
+		offset = next
+	}
+	return nil
+}
+
+// resumeOffset은 스트림 재생을 시작할 offset을 결정합니다
+// 우선순위: Last-Event-ID 헤더 > since 쿼리 > offset 쿼리
+// 이벤트 id는 offset + 1이므로 마지막으로 받은 id가 곧 다음에 읽을 offset입니다
+func resumeOffset(c *gin.Context) (int, error) {
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		seq, err := strconv.Atoi(lastEventID)
+		if err != nil || seq < 0 {
+			return 0, errors.New("Last-Event-ID must be a non-negative integer")
+		}
+		return seq, nil
+	}
+
+	if since := c.Query("since"); since != "" {
+		seq, err := strconv.Atoi(since)
+		if err != nil || seq < 0 {
+			return 0, errors.New("since must be a non-negative integer")
+		}
+		return seq, nil
+	}
 
-// writeSSEEvent는 SSE 형식으로 이벤트를 작성합니다
-func (h *Handlers) writeSSEEvent(w io.Writer, event runner.Event) error {
-	// 형식: event: <type>\ndata: <json>\n\n
-	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, string(event.Data))
-	return err
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		return 0, errors.New("offset must be a non-negative integer")
+	}
+	return offset, nil
 }
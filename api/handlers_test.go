@@ -0,0 +1,281 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"cli-runner/config"
+)
+
+// streamTimeout은 스트림 요청 하나를 기다리는 최대 시간입니다
+const streamTimeout = time.Second
+
+// testConfig는 다섯 줄을 출력하고 끝나는 echo 커넥터를 사용하는 설정을 반환합니다
+func testConfig() *config.Config {
+	return &config.Config{
+		Process: config.ProcessConfig{
+			DefaultTimeout: time.Minute,
+			MaxConcurrent:  4,
+			MaxQueue:       4,
+			BufferSize:     100,
+			CleanupDelay:   time.Minute,
+		},
+		Connectors: config.ConnectorsConfig{
+			"echo": {
+				Type:        "generic",
+				Command:     "sh",
+				Args:        []string{"-c", "for i in 1 2 3 4 5; do echo line $i; done"},
+				Available:   true,
+				VersionArgs: []string{"-c", "echo 1.0"},
+				Prompt:      config.PromptConfig{Mode: "stdin"},
+			},
+		},
+		Probe: config.ProbeConfig{Timeout: 5 * time.Second},
+		Stream: config.StreamConfig{
+			Backpressure:     "block",
+			BlockTimeout:     time.Second,
+			SubscriberBuffer: 100,
+		},
+	}
+}
+
+// newTestServer는 cfg로 라우트를 구성한 서버를 생성합니다
+func newTestServer(t *testing.T, cfg *config.Config) *Server {
+	t.Helper()
+	s, err := NewServer(cfg, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetupRoutes()
+	t.Cleanup(func() {
+		s.registry.StopProbing()
+		s.manager.StopCleanup()
+		s.Close()
+	})
+	return s
+}
+
+// request는 token(비어있으면 인증 헤더 없음)으로 요청을 보내고 응답을 반환합니다
+func request(s *Server, method, path, token, body string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	return w
+}
+
+// stream은 GET /stream/{id} 요청을 보내고 응답을 반환합니다
+// 스트림이 닫히지 않아도 테스트가 멈추지 않도록 streamTimeout 뒤에는 클라이언트 연결을 끊습니다
+func stream(s *Server, path string, header ...string) *httptest.ResponseRecorder {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	return w
+}
+
+// run은 echo 커넥터로 프로세스를 실행하고 ID를 반환합니다
+func run(t *testing.T, s *Server, token string) string {
+	t.Helper()
+	w := request(s, http.MethodPost, "/api/v1/run", token, `{"connector":"echo","prompt":"hello"}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("POST /run = %d %s, want 202", w.Code, w.Body)
+	}
+	var response struct{ ProcessID string }
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	return response.ProcessID
+}
+
+// streamIDs는 SSE 응답에서 id 필드와 마지막 이벤트 타입을 읽습니다
+func streamIDs(t *testing.T, body io.Reader) ([]int, string) {
+	t.Helper()
+	var ids []int
+	var last string
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "id: "); ok {
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				t.Fatalf("invalid id line %q", line)
+			}
+			ids = append(ids, id)
+		}
+		if value, ok := strings.CutPrefix(line, "event: "); ok {
+			last = value
+		}
+	}
+	return ids, last
+}
+
+func TestStreamResume(t *testing.T) {
+	s := newTestServer(t, testConfig())
+	id := run(t, s, "")
+
+	// 처음부터 받은 전체 스트림은 done 이벤트로 끝남
+	w := stream(s, "/api/v1/stream/"+id)
+	all, last := streamIDs(t, w.Body)
+	if last != "done" || len(all) < 6 {
+		t.Fatalf("stream ended with %q after ids %v, want at least 5 lines and done", last, all)
+	}
+	for i, got := range all {
+		if got != i+1 {
+			t.Fatalf("ids = %v, want consecutive ids from 1", all)
+		}
+	}
+	total := len(all)
+
+	tests := []struct {
+		name        string
+		query       string
+		lastEventID string
+		from        int // 첫 이벤트 id (total보다 크면 이벤트 없음)
+		wantStatus  int
+	}{
+		{name: "offset", query: "offset=2", from: 3, wantStatus: http.StatusOK},
+		{name: "since", query: "since=4", from: 5, wantStatus: http.StatusOK},
+		{name: "since over offset", query: "since=4&offset=1", from: 5, wantStatus: http.StatusOK},
+		{name: "Last-Event-ID over since", query: "since=1", lastEventID: "3", from: 4, wantStatus: http.StatusOK},
+		{name: "Last-Event-ID zero replays everything", query: "since=4", lastEventID: "0", from: 1, wantStatus: http.StatusOK},
+		{name: "past the end", query: "offset=1000", from: total + 1, wantStatus: http.StatusOK},
+		{name: "invalid Last-Event-ID", lastEventID: "abc", wantStatus: http.StatusBadRequest},
+		{name: "negative Last-Event-ID", lastEventID: "-1", wantStatus: http.StatusBadRequest},
+		{name: "negative since", query: "since=-1", wantStatus: http.StatusBadRequest},
+		{name: "invalid offset", query: "offset=abc", wantStatus: http.StatusBadRequest},
+		{name: "negative offset", query: "offset=-1", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header []string
+			if tt.lastEventID != "" {
+				header = []string{"Last-Event-ID", tt.lastEventID}
+			}
+			w := stream(s, "/api/v1/stream/"+id+"?"+tt.query, header...)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d %s, want %d", w.Code, w.Body, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			ids, _ := streamIDs(t, w.Body)
+			if want := all[min(tt.from-1, total):]; !slices.Equal(ids, want) {
+				t.Errorf("ids = %v, want %v", ids, want)
+			}
+		})
+	}
+}
+
+func TestRunTenantQuota(t *testing.T) {
+	cfg := testConfig()
+	cfg.Auth.Keys = []config.APIKeyConfig{
+		testKey("alice", "team", ScopeRun),
+		testKey("bob", "team", ScopeRun),
+		testKey("carol", "ops", ScopeRun),
+	}
+	cfg.Tenants.Quotas = map[string]config.TenantQuota{"team": {MaxDailyRuns: 2}}
+	s := newTestServer(t, cfg)
+
+	// 같은 테넌트의 키는 일일 실행 수를 함께 사용
+	run(t, s, "alice-key")
+	run(t, s, "bob-key")
+
+	w := request(s, http.MethodPost, "/api/v1/run", "alice-key", `{"connector":"echo","prompt":"hello"}`)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("POST /run over quota = %d %s, want 429", w.Code, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), "Tenant quota exceeded") {
+		t.Errorf("body = %s, want tenant quota error", w.Body)
+	}
+
+	// 다른 테넌트는 영향을 받지 않음
+	run(t, s, "carol-key")
+}
+
+func TestRunRejectsResume(t *testing.T) {
+	s := newTestServer(t, testConfig())
+
+	// 세션 이어가기는 소유권을 확인하는 continue 엔드포인트만 허용
+	w := request(s, http.MethodPost, "/api/v1/run", "", `{"connector":"echo","prompt":"hello","options":{"resume":"session-1"}}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("POST /run with options.resume = %d %s, want 400", w.Code, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), "/continue") {
+		t.Errorf("body = %s, want a pointer to the continue endpoint", w.Body)
+	}
+	if s.manager.Count() != 0 {
+		t.Errorf("%d processes created, want none", s.manager.Count())
+	}
+}
+
+func TestTenantUsageHandler(t *testing.T) {
+	cfg := testConfig()
+	cfg.Auth.Keys = []config.APIKeyConfig{
+		testKey("alice", "Team", ScopeRun, ScopeRead),
+		testKey("carol", "ops", ScopeRead),
+		testKey("admin", "", ScopeAdmin),
+	}
+	cfg.Tenants.Quotas = map[string]config.TenantQuota{"team": {MaxDailyRuns: 10}}
+	s := newTestServer(t, cfg)
+	run(t, s, "alice-key")
+
+	tests := []struct {
+		name       string
+		token      string
+		tenant     string
+		wantStatus int
+	}{
+		{name: "own tenant", token: "alice-key", tenant: "team", wantStatus: http.StatusOK},
+		{name: "own tenant in another case", token: "alice-key", tenant: "TEAM", wantStatus: http.StatusOK},
+		{name: "other tenant", token: "carol-key", tenant: "team", wantStatus: http.StatusNotFound},
+		{name: "admin", token: "admin-key", tenant: "team", wantStatus: http.StatusOK},
+		{name: "unknown tenant", token: "admin-key", tenant: "nobody", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(s, http.MethodGet, "/api/v1/tenants/"+tt.tenant+"/usage", tt.token, "")
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d %s, want %d", w.Code, w.Body, tt.wantStatus)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var usage TenantUsageResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &usage); err != nil {
+				t.Fatal(err)
+			}
+			if usage.Tenant != "team" || usage.Runs != 1 || usage.Limits.MaxDailyRuns != 10 {
+				t.Errorf("usage = %+v, want tenant team with 1 run and maxDailyRuns 10", usage)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"cli-runner/pkg/metrics"
+	"cli-runner/runner"
+)
+
+// 스트림 구독 방식 (cli_runner_stream_subscribers의 transport 레이블)
+const (
+	transportSSE       = "sse"
+	transportWebSocket = "ws"
+)
+
+// processCollector는 수집 시점에 메모리의 프로세스 수를 커넥터와 상태별로 집계합니다
+// 메모리에서 정리된 프로세스는 포함되지 않으므로 종료된 상태의 수는 process.cleanupDelay 동안의 값입니다
+type processCollector struct {
+	manager   *runner.Manager
+	processes *prometheus.Desc
+	queued    *prometheus.Desc
+}
+
+// newProcessCollector는 매니저의 프로세스 수를 수집하는 Collector를 생성합니다
+func newProcessCollector(manager *runner.Manager) *processCollector {
+	return &processCollector{
+		manager: manager,
+		processes: prometheus.NewDesc(
+			"cli_runner_processes",
+			"Processes held in memory by connector and status.",
+			[]string{"connector", "status"}, nil,
+		),
+		queued: prometheus.NewDesc(
+			"cli_runner_queue_length",
+			"Processes waiting for an execution slot.",
+			nil, nil,
+		),
+	}
+}
+
+// Describe는 prometheus.Collector를 구현합니다
+func (c *processCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.processes
+	ch <- c.queued
+}
+
+// Collect는 prometheus.Collector를 구현합니다
+func (c *processCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, count := range c.manager.StatusCounts() {
+		ch <- prometheus.MustNewConstMetric(c.processes, prometheus.GaugeValue, float64(count.Count), count.Connector, count.Status)
+	}
+	ch <- prometheus.MustNewConstMetric(c.queued, prometheus.GaugeValue, float64(c.manager.QueueLength()))
+}
+
+// metricsMiddleware는 라우트별 요청 처리 시간을 기록합니다
+// 라우트 템플릿(/api/v1/process/:id)을 레이블로 사용하므로 프로세스 ID마다 시계열이 늘지 않습니다
+func (s *Server) metricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
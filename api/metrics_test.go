@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"cli-runner/config"
+)
+
+func TestMetricsAccess(t *testing.T) {
+	cfg := testConfig()
+	cfg.Metrics.Enabled = true
+	cfg.Auth.Keys = []config.APIKeyConfig{
+		testKey("reader", "", ScopeRead),
+		testKey("admin", "", ScopeAdmin),
+	}
+	s := newTestServer(t, cfg)
+	run(t, s, "admin-key")
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{name: "missing key", wantStatus: http.StatusUnauthorized},
+		{name: "scope missing", token: "reader-key", wantStatus: http.StatusForbidden},
+		{name: "admin", token: "admin-key", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(s, http.MethodGet, "/metrics", tt.token, "")
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d %s, want %d", w.Code, w.Body, tt.wantStatus)
+			}
+			if w.Code == http.StatusOK && !strings.Contains(w.Body.String(), `cli_runner_processes{connector="echo"`) {
+				t.Errorf("body does not contain process metrics:\n%s", w.Body)
+			}
+		})
+	}
+}
+
+// 인증을 사용하지 않으면 다른 API와 같이 누구나 조회
+func TestMetricsWithoutAuth(t *testing.T) {
+	cfg := testConfig()
+	cfg.Metrics.Enabled = true
+	s := newTestServer(t, cfg)
+
+	if w := request(s, http.MethodGet, "/metrics", "", ""); w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", w.Code)
+	}
+}
@@ -0,0 +1,268 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"cli-runner/config"
+	"cli-runner/pkg/metrics"
+)
+
+// 속도 제한을 지정할 수 있는 라우트 이름 (rateLimit.routes의 키)
+const (
+	RouteRun         = "run"          // POST /run
+	RouteContinue    = "continue"     // POST /process/{id}/continue
+	RouteStream      = "stream"       // GET /stream/{id}
+	RouteWebSocket   = "ws"           // GET /ws/{id}
+	RouteProcess     = "process"      // GET /process/{id}
+	RouteResult      = "result"       // GET /result/{id}
+	RouteResultData  = "result-data"  // GET /result-data/{id}
+	RouteDelete      = "delete"       // DELETE /process/{id}
+	RouteProcesses   = "processes"    // GET /processes
+	RouteHistory     = "history"      // GET /history
+	RouteConnectors  = "connectors"   // GET /connectors
+	RouteTenantUsage = "tenant-usage" // GET /tenants/{id}/usage
+)
+
+// RateLimitRoutes는 속도 제한을 지정할 수 있는 모든 라우트 이름입니다
+var RateLimitRoutes = []string{
+	RouteRun, RouteContinue, RouteStream, RouteWebSocket, RouteProcess, RouteResult,
+	RouteResultData, RouteDelete, RouteProcesses, RouteHistory, RouteConnectors, RouteTenantUsage,
+}
+
+// maxRejectedClients는 클라이언트별 거부 수를 따로 집계하는 최대 클라이언트 수입니다
+// 넘으면 나머지는 otherClients로 합산합니다 (IP를 바꿔가며 요청해도 메모리가 늘지 않도록)
+const maxRejectedClients = 1000
+
+// otherClients는 집계 한도를 넘은 클라이언트의 거부 수를 합산하는 키입니다
+const otherClients = "other"
+
+// sweepInterval은 가득 찬 (오래 요청하지 않은) 버킷을 정리하는 주기입니다
+const sweepInterval = time.Minute
+
+// bucket은 한 클라이언트의 토큰 버킷입니다
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// rateDecision은 요청 하나에 대한 속도 제한 판단 결과입니다
+type rateDecision struct {
+	allowed    bool
+	remaining  int           // 남은 토큰 수
+	reset      time.Duration // 버킷이 가득 찰 때까지 남은 시간
+	retryAfter time.Duration // 거부된 경우 다음 토큰이 생길 때까지 남은 시간
+}
+
+// RateLimiter는 한 라우트에 대한 클라이언트별 토큰 버킷입니다
+// 버킷은 burst개의 토큰으로 시작하고 requests/per 속도로 보충되며, 요청마다 토큰 하나를 사용합니다
+type RateLimiter struct {
+	route    string
+	rule     config.RateLimitRule
+	rate     float64 // 초당 보충되는 토큰 수
+	burst    float64
+	buckets  map[string]*bucket
+	rejected map[string]uint64 // 클라이언트별 거부 수
+	total    uint64
+	swept    time.Time
+	mu       sync.Mutex
+}
+
+// RateLimitStats는 라우트의 제한 설정과 지금까지 거부한 요청 수입니다
+type RateLimitStats struct {
+	Route      string            `json:"route" example:"run"`
+	Requests   int               `json:"requests" example:"60"`
+	PerSeconds int               `json:"perSeconds" example:"60"`
+	Burst      int               `json:"burst" example:"10"`
+	Rejected   uint64            `json:"rejected" example:"42"`
+	Clients    map[string]uint64 `json:"clients"` // 클라이언트(key:<이름> 또는 ip:<주소>)별 거부 수
+}
+
+// NewRateLimiters는 설정의 라우트별 제한을 검증하여 RateLimiter를 생성합니다
+func NewRateLimiters(cfg config.RateLimitConfig) (map[string]*RateLimiter, error) {
+	limiters := make(map[string]*RateLimiter, len(cfg.Routes))
+
+	for route, rule := range cfg.Routes {
+		if !slices.Contains(RateLimitRoutes, route) {
+			return nil, fmt.Errorf("rateLimit.routes.%s: unknown route (available: %v)", route, RateLimitRoutes)
+		}
+		if rule.Requests <= 0 || rule.Per <= 0 {
+			return nil, fmt.Errorf("rateLimit.routes.%s: requests and per must be positive", route)
+		}
+		if rule.Burst < 0 {
+			return nil, fmt.Errorf("rateLimit.routes.%s: burst must not be negative", route)
+		}
+		if rule.Burst == 0 {
+			rule.Burst = rule.Requests
+		}
+
+		limiters[route] = &RateLimiter{
+			route:    route,
+			rule:     rule,
+			rate:     float64(rule.Requests) / rule.Per.Seconds(),
+			burst:    float64(rule.Burst),
+			buckets:  make(map[string]*bucket),
+			rejected: make(map[string]uint64),
+		}
+	}
+
+	return limiters, nil
+}
+
+// Allow는 client의 버킷에서 토큰 하나를 사용할 수 있는지 판단합니다
+func (l *RateLimiter) Allow(client string, now time.Time) rateDecision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, exists := l.buckets[client]
+	if !exists {
+		b = &bucket{tokens: l.burst, updated: now}
+		l.buckets[client] = b
+	}
+
+	// 마지막 요청 이후 보충된 토큰 반영
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+
+	decision := rateDecision{allowed: b.tokens >= 1}
+	if decision.allowed {
+		b.tokens--
+	} else {
+		decision.retryAfter = l.refillTime(1 - b.tokens)
+		l.reject(client)
+	}
+	decision.remaining = int(b.tokens)
+	decision.reset = l.refillTime(l.burst - b.tokens)
+
+	return decision
+}
+
+// Stats는 라우트의 제한 설정과 거부 수를 반환합니다
+func (l *RateLimiter) Stats() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return RateLimitStats{
+		Route:      l.route,
+		Requests:   l.rule.Requests,
+		PerSeconds: int(l.rule.Per.Seconds()),
+		Burst:      l.rule.Burst,
+		Rejected:   l.total,
+		Clients:    maps.Clone(l.rejected),
+	}
+}
+
+// reject는 거부 수를 집계합니다 (l.mu를 보유한 상태에서 호출)
+func (l *RateLimiter) reject(client string) {
+	l.total++
+	if _, tracked := l.rejected[client]; !tracked && len(l.rejected) >= maxRejectedClients {
+		client = otherClients
+	}
+	l.rejected[client]++
+}
+
+// refillTime은 tokens개의 토큰이 보충되는 데 걸리는 시간을 반환합니다
+func (l *RateLimiter) refillTime(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// sweep은 가득 찰 만큼 오래 요청하지 않은 클라이언트의 버킷을 제거합니다 (l.mu를 보유한 상태에서 호출)
+// 새 버킷도 가득 찬 상태로 시작하므로 제거해도 동작은 같습니다
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < sweepInterval {
+		return
+	}
+	l.swept = now
+
+	for client, b := range l.buckets {
+		if now.Sub(b.updated) >= l.refillTime(l.burst-b.tokens) {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// rateLimit은 라우트에 설정된 속도 제한을 적용합니다 (설정이 없으면 제한하지 않음)
+// 클라이언트는 인증된 API 키로 구분하며, 인증을 사용하지 않으면 IP로 구분합니다
+func (s *Server) rateLimit(route string) gin.HandlerFunc {
+	limiter, ok := s.limiters[route]
+	if !ok {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		client := rateLimitClient(c)
+		decision := limiter.Allow(client, time.Now())
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limiter.rule.Burst))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.reset)))
+
+		if !decision.allowed {
+			retryAfter := ceilSeconds(decision.retryAfter)
+			s.logger.Debug().
+				Str("route", route).
+				Str("client", client).
+				Int("retryAfter", retryAfter).
+				Msg("Rate limit exceeded")
+			metrics.RateLimited.WithLabelValues(route).Inc()
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":   "Rate limit exceeded",
+				"details": fmt.Sprintf("retry after %d seconds", retryAfter),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitClient는 속도 제한 버킷을 구분하는 클라이언트 키를 반환합니다
+func rateLimitClient(c *gin.Context) string {
+	if principal := principalFrom(c); principal != nil {
+		return "key:" + principal.Name
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds는 d를 초 단위로 올림합니다
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// RateLimitsResponse는 라우트별 속도 제한과 거부 수를 나타냅니다
+type RateLimitsResponse struct {
+	Routes []RateLimitStats `json:"routes"`
+}
+
+// RateLimitsHandler handles GET /api/v1/rate-limits
+// @Summary 속도 제한 현황
+// @Description 라우트별 속도 제한 설정과 서버 시작 이후 거부한 요청 수를 클라이언트별로 조회합니다 (admin 전용)
+// @Tags admin
+// @Produce json
+// @Success 200 {object} RateLimitsResponse "라우트별 속도 제한 현황"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
+// @Security BearerAuth
+// @Router /rate-limits [get]
+func (s *Server) RateLimitsHandler(c *gin.Context) {
+	routes := make([]RateLimitStats, 0, len(s.limiters))
+	for _, limiter := range s.limiters {
+		routes = append(routes, limiter.Stats())
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Route < routes[j].Route
+	})
+
+	c.JSON(http.StatusOK, RateLimitsResponse{Routes: routes})
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"cli-runner/config"
+)
+
+// newTestLimiter는 run 라우트에 rule을 적용하는 RateLimiter를 생성합니다
+func newTestLimiter(t *testing.T, rule config.RateLimitRule) *RateLimiter {
+	t.Helper()
+	limiters, err := NewRateLimiters(config.RateLimitConfig{
+		Routes: map[string]config.RateLimitRule{RouteRun: rule},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return limiters[RouteRun]
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 요청 하나의 시각과 기대하는 판단 결과
+	type step struct {
+		at         time.Duration // start로부터의 시간
+		allowed    bool
+		remaining  int
+		reset      time.Duration
+		retryAfter time.Duration
+	}
+
+	tests := []struct {
+		name  string
+		rule  config.RateLimitRule
+		steps []step
+	}{
+		{
+			name: "burst then refill",
+			rule: config.RateLimitRule{Requests: 1, Per: 2 * time.Second, Burst: 2},
+			steps: []step{
+				{at: 0, allowed: true, remaining: 1, reset: 2 * time.Second},
+				{at: 0, allowed: true, remaining: 0, reset: 4 * time.Second},
+				{at: 0, allowed: false, remaining: 0, reset: 4 * time.Second, retryAfter: 2 * time.Second},
+				{at: time.Second, allowed: false, remaining: 0, reset: 3 * time.Second, retryAfter: time.Second},
+				{at: 2 * time.Second, allowed: true, remaining: 0, reset: 4 * time.Second},
+				// 오래 쉬어도 burst까지만 보충
+				{at: 30 * time.Second, allowed: true, remaining: 1, reset: 2 * time.Second},
+			},
+		},
+		{
+			name: "burst defaults to requests",
+			rule: config.RateLimitRule{Requests: 2, Per: time.Second},
+			steps: []step{
+				{at: 0, allowed: true, remaining: 1, reset: 500 * time.Millisecond},
+				{at: 0, allowed: true, remaining: 0, reset: time.Second},
+				{at: 0, allowed: false, remaining: 0, reset: time.Second, retryAfter: 500 * time.Millisecond},
+				{at: 250 * time.Millisecond, allowed: false, remaining: 0, reset: 750 * time.Millisecond, retryAfter: 250 * time.Millisecond},
+				{at: 500 * time.Millisecond, allowed: true, remaining: 0, reset: time.Second},
+			},
+		},
+		{
+			name: "burst of one",
+			rule: config.RateLimitRule{Requests: 60, Per: time.Minute, Burst: 1},
+			steps: []step{
+				{at: 0, allowed: true, remaining: 0, reset: time.Second},
+				{at: 400 * time.Millisecond, allowed: false, remaining: 0, reset: 600 * time.Millisecond, retryAfter: 600 * time.Millisecond},
+				{at: time.Second, allowed: true, remaining: 0, reset: time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := newTestLimiter(t, tt.rule)
+
+			for i, want := range tt.steps {
+				got := limiter.Allow("key:alice", start.Add(want.at))
+				if got.allowed != want.allowed || got.remaining != want.remaining {
+					t.Errorf("step %d: allowed=%v remaining=%d, want allowed=%v remaining=%d",
+						i, got.allowed, got.remaining, want.allowed, want.remaining)
+				}
+				if !approx(got.reset, want.reset) {
+					t.Errorf("step %d: reset=%s, want %s", i, got.reset, want.reset)
+				}
+				if !approx(got.retryAfter, want.retryAfter) {
+					t.Errorf("step %d: retryAfter=%s, want %s", i, got.retryAfter, want.retryAfter)
+				}
+			}
+
+			// 다른 클라이언트는 자신의 버킷을 사용
+			if got := limiter.Allow("key:bob", start); !got.allowed {
+				t.Error("another client was rejected")
+			}
+		})
+	}
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestLimiter(t, config.RateLimitRule{Requests: 1, Per: 10 * time.Second, Burst: 2})
+
+	limiter.Allow("ip:a", start)                     // 10초 뒤 다시 가득 참
+	limiter.Allow("ip:c", start.Add(55*time.Second)) // 정리 시점에 아직 보충 중
+	limiter.Allow("ip:b", start.Add(sweepInterval+time.Second))
+
+	var clients []string
+	for client := range limiter.buckets {
+		clients = append(clients, client)
+	}
+	slices.Sort(clients)
+
+	if want := []string{"ip:b", "ip:c"}; !slices.Equal(clients, want) {
+		t.Errorf("buckets after sweep = %v, want %v", clients, want)
+	}
+}
+
+func TestRateLimiterStats(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestLimiter(t, config.RateLimitRule{Requests: 1, Per: time.Minute, Burst: 1})
+
+	// 집계 한도를 넘는 클라이언트의 거부는 other로 합산
+	for i := 0; i <= maxRejectedClients; i++ {
+		client := fmt.Sprintf("ip:10.0.%d.%d", i/256, i%256)
+		limiter.Allow(client, now)
+		limiter.Allow(client, now)
+	}
+	limiter.Allow("ip:10.0.0.0", now)
+
+	stats := limiter.Stats()
+	if stats.Rejected != maxRejectedClients+2 {
+		t.Errorf("rejected = %d, want %d", stats.Rejected, maxRejectedClients+2)
+	}
+	if len(stats.Clients) != maxRejectedClients+1 {
+		t.Errorf("tracked clients = %d, want %d", len(stats.Clients), maxRejectedClients+1)
+	}
+	if stats.Clients["ip:10.0.0.0"] != 2 || stats.Clients[otherClients] != 1 {
+		t.Errorf("clients[first] = %d, clients[other] = %d, want 2 and 1",
+			stats.Clients["ip:10.0.0.0"], stats.Clients[otherClients])
+	}
+}
+
+func TestNewRateLimitersValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    config.RateLimitRule
+		route   string
+		wantErr bool
+	}{
+		{name: "valid", route: RouteRun, rule: config.RateLimitRule{Requests: 10, Per: time.Minute, Burst: 5}},
+		{name: "unknown route", route: "unknown", rule: config.RateLimitRule{Requests: 10, Per: time.Minute}, wantErr: true},
+		{name: "zero requests", route: RouteRun, rule: config.RateLimitRule{Per: time.Minute}, wantErr: true},
+		{name: "zero period", route: RouteRun, rule: config.RateLimitRule{Requests: 10}, wantErr: true},
+		{name: "negative burst", route: RouteRun, rule: config.RateLimitRule{Requests: 10, Per: time.Minute, Burst: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRateLimiters(config.RateLimitConfig{
+				Routes: map[string]config.RateLimitRule{tt.route: tt.rule},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRateLimiters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// limitedRequest는 remoteAddr에서 GET /connectors 요청을 보냅니다
+func limitedRequest(s *Server, remoteAddr string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/connectors", nil)
+	req.RemoteAddr = remoteAddr
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	cfg := testConfig()
+	cfg.RateLimit.Routes = map[string]config.RateLimitRule{
+		RouteConnectors: {Requests: 1, Per: time.Minute, Burst: 2},
+	}
+	s := newTestServer(t, cfg)
+
+	for i, wantRemaining := range []string{"1", "0"} {
+		w := limitedRequest(s, "10.0.0.1:1000")
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d = %d, want 200", i+1, w.Code)
+		}
+		if got := w.Header().Get("X-RateLimit-Remaining"); got != wantRemaining {
+			t.Errorf("request %d: X-RateLimit-Remaining = %s, want %s", i+1, got, wantRemaining)
+		}
+	}
+
+	w := limitedRequest(s, "10.0.0.1:1000")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("request over burst = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("Retry-After = %q, want 60", got)
+	}
+	if got := w.Header().Get("X-RateLimit-Limit"); got != "2" {
+		t.Errorf("X-RateLimit-Limit = %q, want 2", got)
+	}
+	if got := w.Header().Get("X-RateLimit-Reset"); got != "120" {
+		t.Errorf("X-RateLimit-Reset = %q, want 120", got)
+	}
+
+	// 다른 IP는 자신의 버킷을 사용
+	if w := limitedRequest(s, "10.0.0.2:1000"); w.Code != http.StatusOK {
+		t.Errorf("request from another IP = %d, want 200", w.Code)
+	}
+
+	// 제한이 없는 라우트는 영향을 받지 않음
+	if w := request(s, http.MethodGet, "/api/v1/processes", "", ""); w.Code != http.StatusOK {
+		t.Errorf("GET /processes = %d, want 200", w.Code)
+	}
+}
+
+func TestRateLimitForwardedFor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		wantStatus     int // 같은 프록시를 거친 두 번째 클라이언트의 응답
+	}{
+		// 신뢰하지 않는 클라이언트가 보낸 X-Forwarded-For로는 제한을 피할 수 없음
+		{name: "untrusted header is ignored", wantStatus: http.StatusTooManyRequests},
+		// 신뢰하는 프록시 뒤의 클라이언트는 각자의 버킷을 사용
+		{name: "trusted proxy", trustedProxies: []string{"10.0.0.1"}, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.Server.TrustedProxies = tt.trustedProxies
+			cfg.RateLimit.Routes = map[string]config.RateLimitRule{
+				RouteConnectors: {Requests: 1, Per: time.Minute, Burst: 1},
+			}
+			s := newTestServer(t, cfg)
+
+			if w := limitedRequest(s, "10.0.0.1:1000", "X-Forwarded-For", "203.0.113.1"); w.Code != http.StatusOK {
+				t.Fatalf("first request = %d, want 200", w.Code)
+			}
+			if w := limitedRequest(s, "10.0.0.1:1000", "X-Forwarded-For", "203.0.113.2"); w.Code != tt.wantStatus {
+				t.Errorf("second request = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// approx는 부동소수점 계산 오차를 허용하여 두 시간을 비교합니다
+func approx(got, want time.Duration) bool {
+	diff := got - want
+	return diff > -time.Microsecond && diff < time.Microsecond
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -11,39 +13,95 @@ import (
 
 	"cli-runner/config"
 	"cli-runner/connector"
+	"cli-runner/pkg/metrics"
 	"cli-runner/runner"
+	"cli-runner/store"
+)
+
+// 서버 종료 방식 (server.shutdown.mode)
+const (
+	ShutdownModeDrain = "drain" // 실행 중인 프로세스가 끝나기를 drainTimeout까지 기다림
+	ShutdownModeStop  = "stop"  // 실행 중인 프로세스를 즉시 중지
 )
 
 // Server는 모든 의존성을 가진 HTTP 서버를 나타냅니다
 type Server struct {
 	engine   *gin.Engine
+	http     *http.Server
 	config   *config.Config
 	logger   zerolog.Logger
 	manager  *runner.Manager
 	runner   *runner.Runner
 	registry *connector.Registry
+	store    runner.ProcessStore // store.path가 비어있으면 nil
+	eventLog runner.EventLog     // store.events.dir가 비어있으면 nil
 	handlers *Handlers
+	auth     *Authenticator
+	limiters map[string]*RateLimiter // 라우트 이름 → 속도 제한 (설정된 라우트만)
 }
 
 // NewServer는 제공된 설정과 로거로 새로운 Server 인스턴스를 생성합니다
-func NewServer(cfg *config.Config, logger zerolog.Logger) *Server {
+func NewServer(cfg *config.Config, logger zerolog.Logger) (*Server, error) {
 	// 로그 레벨에 따라 Gin 모드 설정 (프로덕션은 release 모드 사용)
 	if cfg.Logging.Level != "debug" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// 매니저 생성
-	manager := runner.NewManager(cfg, logger)
+	// API 키 인증 설정 (auth.keys가 비어있으면 인증하지 않음)
+	auth, err := NewAuthenticator(cfg.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth configuration: %w", err)
+	}
+	if !auth.Enabled() {
+		logger.Warn().Msg("API authentication is disabled: every client can run, read and stop all processes")
+	}
+
+	// 라우트별 요청 속도 제한 설정
+	limiters, err := NewRateLimiters(cfg.RateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rateLimit configuration: %w", err)
+	}
+
+	// 프로세스 저장소 열기 (store.path가 비어있으면 메모리에만 보관)
+	var processStore runner.ProcessStore
+	if cfg.Store.Path != "" {
+		boltStore, err := store.Open(cfg.Store.Path, logger)
+		if err != nil {
+			return nil, err
+		}
+		processStore = boltStore
+	}
+
+	// 이벤트 로그 열기 (store.events.dir가 비어있으면 링 버퍼만 사용)
+	var eventLog runner.EventLog
+	if cfg.Store.Events.Dir != "" {
+		segmentLog, err := store.OpenSegmentLog(cfg.Store.Events.Dir, cfg.Store.Events.SegmentSize, logger)
+		if err != nil {
+			closeStore(processStore, nil)
+			return nil, err
+		}
+		eventLog = segmentLog
+	}
+
+	// 매니저 생성 후 이전 실행에서 중단된 기록 복구
+	manager := runner.NewManager(cfg, processStore, eventLog, logger)
+	if err := manager.Recover(); err != nil {
+		closeStore(processStore, eventLog)
+		return nil, fmt.Errorf("failed to recover processes: %w", err)
+	}
 
 	// 러너 생성
 	runnerInstance := runner.NewRunner(manager, logger)
 
 	// 커넥터 레지스트리 생성
-	registry := connector.NewRegistry()
-	registry.SetupFromConfig(cfg)
+	registry := connector.NewRegistry(logger)
+	if err := registry.SetupFromConfig(cfg); err != nil {
+		closeStore(processStore, eventLog)
+		return nil, fmt.Errorf("failed to setup connectors: %w", err)
+	}
 
 	// 핸들러 생성
-	handlers := NewHandlers(manager, runnerInstance, registry, logger)
+	handlers := NewHandlers(cfg, manager, runnerInstance, registry, limiters, logger)
 
 	s := &Server{
 		engine:   gin.New(),
@@ -52,14 +110,36 @@ func NewServer(cfg *config.Config, logger zerolog.Logger) *Server {
 		manager:  manager,
 		runner:   runnerInstance,
 		registry: registry,
+		store:    processStore,
+		eventLog: eventLog,
 		handlers: handlers,
+		auth:     auth,
+		limiters: limiters,
+	}
+
+	// 클라이언트 IP (속도 제한, 로그)는 신뢰하는 프록시가 보낸 헤더만 사용
+	if err := s.engine.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		closeStore(processStore, eventLog)
+		return nil, fmt.Errorf("invalid server.trustedProxies: %w", err)
+	}
+
+	// 0이면 타임아웃 없음 (SSE 스트림은 writeTimeout에서 제외, WebSocket은 연결 전환 후 적용되지 않음)
+	s.http = &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
+		Handler:           s.engine,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
 	}
 
 	// 미들웨어 추가
 	s.engine.Use(gin.Recovery())
 	s.engine.Use(s.loggingMiddleware())
+	if cfg.Metrics.Enabled {
+		s.engine.Use(s.metricsMiddleware())
+	}
 
-	return s
+	return s, nil
 }
 
 // SetupRoutes는 모든 HTTP 라우트를 구성합니다
@@ -68,34 +148,98 @@ func (s *Server) SetupRoutes() {
 	s.engine.GET("/health", s.healthHandler)
 	s.engine.GET("/ready", s.readyHandler)
 
+	// Prometheus 메트릭 (커넥터와 라우트별 사용량이 드러나므로 admin 키만 조회)
+	if s.config.Metrics.Enabled {
+		if err := metrics.Registry.Register(newProcessCollector(s.manager)); err != nil {
+			s.logger.Warn().Err(err).Msg("Failed to register process metrics")
+		}
+		s.engine.GET("/metrics", s.authenticate(), s.requireScope(ScopeAdmin), gin.WrapH(metrics.Handler()))
+	}
+
 	// Swagger UI
 	s.engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// API 라우트 그룹
-	api := s.engine.Group("/api/v1")
+	// API 라우트 그룹 (API 키 인증 후 라우트별 속도 제한과 권한 확인)
+	api := s.engine.Group("/api/v1", s.authenticate())
 	{
-		api.POST("/run", s.handlers.RunHandler)
-		api.GET("/stream/:id", s.handlers.StreamHandler)
-		api.GET("/process/:id", s.handlers.GetProcessHandler)
-		api.GET("/result/:id", s.handlers.GetResultHandler)
-		api.GET("/result-data/:id", s.handlers.GetResultDataHandler)
-		api.DELETE("/process/:id", s.handlers.DeleteProcessHandler)
-		api.GET("/processes", s.handlers.ListProcessesHandler)
-		api.GET("/connectors", s.handlers.ListConnectorsHandler)
+		run := s.requireScope(ScopeRun)
+		read := s.requireScope(ScopeRead)
+		stop := s.requireScope(ScopeStop)
+		admin := s.requireScope(ScopeAdmin)
+
+		api.POST("/run", s.rateLimit(RouteRun), run, s.handlers.RunHandler)
+		api.GET("/stream/:id", s.rateLimit(RouteStream), read, s.handlers.StreamHandler)
+		api.GET("/ws/:id", s.rateLimit(RouteWebSocket), read, s.handlers.WebSocketHandler)
+		api.GET("/process/:id", s.rateLimit(RouteProcess), read, s.handlers.GetProcessHandler)
+		api.GET("/result/:id", s.rateLimit(RouteResult), read, s.handlers.GetResultHandler)
+		api.GET("/result-data/:id", s.rateLimit(RouteResultData), read, s.handlers.GetResultDataHandler)
+		api.DELETE("/process/:id", s.rateLimit(RouteDelete), stop, s.handlers.DeleteProcessHandler)
+		api.POST("/process/:id/continue", s.rateLimit(RouteContinue), run, s.handlers.ContinueHandler)
+		api.GET("/processes", s.rateLimit(RouteProcesses), read, s.handlers.ListProcessesHandler)
+		api.GET("/history", s.rateLimit(RouteHistory), read, s.handlers.HistoryHandler)
+		api.GET("/connectors", s.rateLimit(RouteConnectors), read, s.handlers.ListConnectorsHandler)
+		api.GET("/tenants/:id/usage", s.rateLimit(RouteTenantUsage), read, s.handlers.TenantUsageHandler)
+		api.GET("/rate-limits", admin, s.RateLimitsHandler)
 	}
 
+	// 커넥터 가용성 검사 (시작 시 1회 + 주기적)
+	s.registry.StartProbing(s.config.Probe.Interval, s.config.Probe.Timeout)
+
 	// 클린업 고루틴 시작
 	s.manager.StartCleanup()
 }
 
 // Run은 HTTP 서버를 시작합니다
+// Shutdown으로 종료되면 nil을 반환합니다
 func (s *Server) Run() error {
-	addr := fmt.Sprintf("%s:%d", s.config.Server.Host, s.config.Server.Port)
 	s.logger.Info().
-		Str("address", addr).
+		Str("address", s.http.Addr).
 		Msg("Starting HTTP server")
 
-	return s.engine.Run(addr)
+	if err := s.http.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown은 서버를 정상 종료합니다
+// 새 실행 요청을 거부하고 server.shutdown.mode에 따라 실행 중인 프로세스를 기다리거나 중지한 뒤
+// (구독자는 reason이 server_shutdown인 done 이벤트를 받음) 스트림이 닫히면 HTTP 서버와 저장소를 닫습니다
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.registry.StopProbing()
+
+	drainCtx := ctx
+	drain := s.config.Server.Shutdown.Mode != ShutdownModeStop
+	if drain {
+		var cancel context.CancelFunc
+		drainCtx, cancel = context.WithTimeout(ctx, s.config.Server.Shutdown.DrainTimeout)
+		defer cancel()
+	}
+	s.manager.Shutdown(drainCtx, drain)
+
+	// 모든 프로세스가 끝났으므로 SSE 스트림은 done 이벤트 후 닫히고 WebSocket 세션도 남은 이벤트를 보낸 뒤 닫음
+	s.handlers.Shutdown()
+	err := s.http.Shutdown(ctx)
+
+	s.logger.Info().Msg("HTTP server stopped")
+	return errors.Join(err, s.Close())
+}
+
+// Close는 서버가 사용하는 자원(프로세스 저장소, 이벤트 로그)을 정리합니다
+func (s *Server) Close() error {
+	return closeStore(s.store, s.eventLog)
+}
+
+// closeStore는 설정된 저장소와 이벤트 로그의 예약된 쓰기를 반영하고 닫습니다
+func closeStore(processStore runner.ProcessStore, eventLog runner.EventLog) error {
+	var errs []error
+	if processStore != nil {
+		errs = append(errs, processStore.Close())
+	}
+	if eventLog != nil {
+		errs = append(errs, eventLog.Close())
+	}
+	return errors.Join(errs...)
 }
 
 // healthHandler는 기본 상태 정보를 반환합니다
@@ -106,9 +250,40 @@ func (s *Server) healthHandler(c *gin.Context) {
 }
 
 // readyHandler는 서버가 요청을 받을 준비가 되었는지 확인합니다
+// 종료 중이거나, 저장소 쓰기가 실패하고 있거나, 사용 가능한 커넥터가 하나도 없으면 준비되지 않은 것으로 판단합니다
 func (s *Server) readyHandler(c *gin.Context) {
+	if s.manager.ShuttingDown() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status": "not ready",
+			"reason": "shutting down",
+		})
+		return
+	}
+
+	// 저장소에 기록을 반영하지 못하고 있으면 재시작 후 조회할 수 없으므로 준비되지 않은 것으로 판단
+	if health, ok := s.store.(runner.StoreHealth); ok {
+		if err := health.Err(); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status":  "not ready",
+				"reason":  "process store write failed",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	available := s.registry.Available()
+	if len(available) == 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status": "not ready",
+			"reason": "no connector available",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"status": "ready",
+		"status":     "ready",
+		"connectors": available,
 	})
 }
 
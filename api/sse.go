@@ -13,12 +13,13 @@ import (
 
 // SSEWriter는 SSE 이벤트 작성을 돕습니다
 type SSEWriter struct {
-	c *gin.Context
+	c      *gin.Context
+	format string // data 형식: raw (CLI 원본 JSON) 또는 envelope (전체 Event)
 }
 
 // NewSSEWriter는 새로운 SSE writer를 생성합니다
-func NewSSEWriter(c *gin.Context) *SSEWriter {
-	return &SSEWriter{c: c}
+func NewSSEWriter(c *gin.Context, format string) *SSEWriter {
+	return &SSEWriter{c: c, format: format}
 }
 
 // SetHeaders는 SSE에 필요한 헤더를 설정합니다
@@ -29,12 +30,70 @@ func (w *SSEWriter) SetHeaders() {
 	w.c.Header("X-Accel-Buffering", "no") // Nginx 버퍼링 비활성화
 }
 
+// WriteRetry는 연결이 끊겼을 때 클라이언트가 재연결까지 기다릴 시간을 알립니다
+// 형식: retry: <milliseconds>\n\n
+func (w *SSEWriter) WriteRetry(retry time.Duration) error {
+	if _, err := fmt.Fprintf(w.c.Writer, "retry: %d\n\n", retry.Milliseconds()); err != nil {
+		return fmt.Errorf("failed to write retry: %w", err)
+	}
+
+	w.Flush()
+	return nil
+}
+
+// WritePing은 프록시가 유휴 연결을 끊지 않도록 주석 프레임을 작성합니다
+// 형식: : ping\n\n (EventSource는 주석을 무시함)
+func (w *SSEWriter) WritePing() error {
+	if _, err := fmt.Fprint(w.c.Writer, ": ping\n\n"); err != nil {
+		return fmt.Errorf("failed to write ping: %w", err)
+	}
+
+	w.Flush()
+	return nil
+}
+
 // WriteEvent는 SSE 형식으로 단일 이벤트를 작성합니다
-// 형식: event: <type>\ndata: <json>\n\n
 func (w *SSEWriter) WriteEvent(event runner.Event) error {
-	data, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+	if err := w.write(event); err != nil {
+		return err
+	}
+
+	w.Flush()
+	return nil
+}
+
+// WriteEvents는 여러 이벤트를 작성한 뒤 한 번만 플러시합니다 (재생용)
+func (w *SSEWriter) WriteEvents(events []runner.Event) error {
+	for _, event := range events {
+		if err := w.write(event); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return nil
+}
+
+// write는 이벤트 하나를 플러시하지 않고 작성합니다
+// 형식: id: <seq>\nevent: <type>\ndata: <json>\n\n
+// 클라이언트는 재연결 시 마지막 id를 Last-Event-ID로 보내 이어서 받음
+// gap 이벤트는 순번이 없으므로 id를 생략하여 마지막 id를 유지
+func (w *SSEWriter) write(event runner.Event) error {
+	data := []byte(event.Data)
+	if w.format == streamFormatEnvelope {
+		// 원본 Data와 정규화된 이벤트를 함께 전달
+		envelope, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		data = envelope
+	}
+
+	// 이벤트 ID 라인 작성
+	if event.Seq > 0 {
+		if _, err := fmt.Fprintf(w.c.Writer, "id: %d\n", event.Seq); err != nil {
+			return fmt.Errorf("failed to write event id: %w", err)
+		}
 	}
 
 	// 이벤트 타입 라인 작성
@@ -47,7 +106,6 @@ func (w *SSEWriter) WriteEvent(event runner.Event) error {
 		return fmt.Errorf("failed to write event data: %w", err)
 	}
 
-	w.Flush()
 	return nil
 }
 
@@ -0,0 +1,492 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"cli-runner/pkg/metrics"
+	"cli-runner/runner"
+)
+
+// WebSocket 클라이언트 메시지 타입
+const (
+	wsStop        = "stop"        // 프로세스 중지
+	wsInput       = "input"       // 종료된 프로세스의 세션을 이어서 새 프로세스 실행
+	wsSubscribe   = "subscribe"   // 다른 프로세스의 이벤트 구독
+	wsUnsubscribe = "unsubscribe" // 구독 해제
+	wsPing        = "ping"        // 연결 확인
+)
+
+// WebSocket 서버 메시지 타입
+const (
+	wsEvent = "event" // 프로세스 이벤트 (runner.Event)
+	wsAck   = "ack"   // 제어 메시지 처리 완료
+	wsError = "error" // 제어 메시지 처리 실패
+	wsPong  = "pong"  // ping 응답
+)
+
+// wsWriteTimeout은 메시지 하나를 쓰는 최대 시간입니다
+const wsWriteTimeout = 10 * time.Second
+
+// WSClientMessage는 클라이언트가 보내는 제어 메시지입니다
+type WSClientMessage struct {
+	Type            string `json:"type" example:"stop"`                                                // stop, input, subscribe, unsubscribe, ping
+	ID              string `json:"id,omitempty" example:"req-1"`                                       // 응답(ack, error)에 그대로 돌려주는 요청 ID
+	ProcessID       string `json:"processId,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // 대상 프로세스 (input이면 이어갈 프로세스)
+	Since           int    `json:"since,omitempty" example:"42"`                                       // subscribe: 마지막으로 받은 이벤트 id
+	ContinueRequest        // input: POST /process/:id/continue와 같은 필드
+}
+
+// WSServerMessage는 서버가 보내는 메시지입니다
+type WSServerMessage struct {
+	Type      string        `json:"type" example:"event"` // event, ack, error, pong
+	ID        string        `json:"id,omitempty" example:"req-1"`
+	ProcessID string        `json:"processId,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Event     *runner.Event `json:"event,omitempty" swaggertype:"object"`  // ?format=envelope의 Event와 같음
+	Result    gin.H         `json:"result,omitempty" swaggertype:"object"` // ack의 처리 결과 (input이면 새 processId)
+	Error     string        `json:"error,omitempty"`
+}
+
+// wsSession은 하나의 WebSocket 연결과 그 연결의 구독들입니다
+// 모든 쓰기는 writeLoop 고루틴 하나에서 수행됩니다 (gorilla/websocket은 동시 쓰기를 허용하지 않음)
+type wsSession struct {
+	h             *Handlers
+	principal     *Principal // 연결한 API 키 (인증을 사용하지 않으면 nil)
+	client        string     // 속도 제한 클라이언트 키
+	conn          *websocket.Conn
+	ctx           context.Context
+	cancel        context.CancelFunc
+	send          chan WSServerMessage
+	backpressure  string
+	subscriptions map[string]*wsSubscription // processID → 구독
+	closing       bool                       // 서버 종료 중 (마지막 구독이 끝나면 연결을 닫음)
+	wg            sync.WaitGroup
+	mu            sync.Mutex
+}
+
+// wsSubscription은 세션의 프로세스 구독 하나입니다
+type wsSubscription struct {
+	cancel context.CancelFunc
+}
+
+// WebSocketHandler handles GET /api/v1/ws/:id
+// @Summary WebSocket 세션
+// @Description 프로세스 이벤트(runner.Event)를 WebSocket으로 스트리밍하고 제어 메시지(stop, input, subscribe, unsubscribe, ping)를 받습니다
+// @Description 하나의 연결로 여러 프로세스를 구독할 수 있으며, 서버 메시지는 processId로 구분합니다
+// @Tags stream
+// @Param id path string true "처음 구독할 프로세스 ID"
+// @Param offset query int false "재생을 시작할 이벤트 위치 (0부터 시작)" default(0)
+// @Param since query int false "마지막으로 받은 이벤트 id, 이후 이벤트부터 전송 (offset보다 우선)"
+// @Param backpressure query string false "클라이언트가 느려 구독 버퍼가 가득 찼을 때의 처리 (기본값: stream.backpressure)" Enums(block, drop, disconnect)
+// @Success 101 {object} WSServerMessage "프로토콜 전환"
+// @Failure 400 {object} ErrorResponse "잘못된 offset, since 또는 backpressure"
+// @Failure 401 {object} ErrorResponse "API 키가 없거나 올바르지 않음"
+// @Failure 403 {object} ErrorResponse "API 키에 필요한 권한(scope)이 없음"
+// @Failure 404 {object} ErrorResponse "프로세스를 찾을 수 없음"
+// @Failure 429 {object} ErrorResponse "요청 속도 제한 초과 (rateLimit.routes)"
+// @Security BearerAuth
+// @Router /ws/{id} [get]
+func (h *Handlers) WebSocketHandler(c *gin.Context) {
+	processID := c.Param("id")
+
+	offset, err := resumeOffset(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid resume position",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	backpressure := c.DefaultQuery("backpressure", h.config.Stream.Backpressure)
+	if !slices.Contains(runner.BackpressurePolicies, backpressure) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid backpressure: must be block, drop or disconnect"})
+		return
+	}
+
+	principal := principalFrom(c)
+	process, err := h.getProcess(principal, processID)
+	if err != nil {
+		h.logger.Warn().
+			Str("processId", processID).
+			Msg("Process not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Process not found"})
+		return
+	}
+
+	upgrader := websocket.Upgrader{CheckOrigin: h.checkOrigin}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade가 이미 에러 응답을 작성함
+		h.logger.Warn().
+			Str("processId", processID).
+			Err(err).
+			Msg("WebSocket upgrade failed")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	session := &wsSession{
+		h:             h,
+		principal:     principal,
+		client:        rateLimitClient(c),
+		conn:          conn,
+		ctx:           ctx,
+		cancel:        cancel,
+		send:          make(chan WSServerMessage, h.config.Stream.SubscriberBuffer),
+		backpressure:  backpressure,
+		subscriptions: make(map[string]*wsSubscription),
+	}
+
+	h.logger.Info().
+		Str("processId", processID).
+		Msg("WebSocket session started")
+
+	go session.writeLoop()
+	go session.closeOnShutdown()
+	session.subscribe(process, offset)
+	session.readLoop()
+
+	// 읽기가 끝나면 (클라이언트 종료 또는 에러) 모든 구독을 정리
+	cancel()
+	session.wg.Wait()
+	conn.Close()
+
+	h.logger.Info().
+		Str("processId", processID).
+		Msg("WebSocket session closed")
+}
+
+// checkOrigin은 stream.allowedOrigins에 따라 교차 출처 WebSocket 연결을 허용합니다
+// 목록이 비어있으면 같은 호스트에서 온 요청만 허용하고, "*"이면 모두 허용합니다
+func (h *Handlers) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	allowed := h.config.Stream.AllowedOrigins
+	if slices.Contains(allowed, "*") || slices.Contains(allowed, origin) {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// readLoop는 연결이 끊길 때까지 클라이언트 메시지를 읽어 처리합니다
+func (s *wsSession) readLoop() {
+	for {
+		var msg WSClientMessage
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) &&
+				!errors.Is(err, context.Canceled) {
+				s.h.logger.Debug().
+					Err(err).
+					Msg("WebSocket read ended")
+			}
+			return
+		}
+		s.handle(msg)
+	}
+}
+
+// handle은 제어 메시지 하나를 처리합니다
+func (s *wsSession) handle(msg WSClientMessage) {
+	switch msg.Type {
+	case wsPing:
+		s.write(WSServerMessage{Type: wsPong, ID: msg.ID})
+
+	case wsSubscribe:
+		process, err := s.h.getProcess(s.principal, msg.ProcessID)
+		if err != nil {
+			s.fail(msg, "Process not found")
+			return
+		}
+		s.subscribe(process, msg.Since)
+		s.ack(msg, nil)
+
+	case wsUnsubscribe:
+		s.mu.Lock()
+		subscription, ok := s.subscriptions[msg.ProcessID]
+		delete(s.subscriptions, msg.ProcessID)
+		s.mu.Unlock()
+		if !ok {
+			s.fail(msg, "Not subscribed to process")
+			return
+		}
+		subscription.cancel()
+		s.ack(msg, nil)
+
+	case wsStop:
+		if !s.principal.Has(ScopeStop) {
+			s.fail(msg, "API key lacks the 'stop' scope")
+			return
+		}
+		if _, reqErr := s.h.ownProcess(s.principal, msg.ProcessID); reqErr != nil {
+			s.fail(msg, reqErr.Error())
+			return
+		}
+
+		// 중지는 유예 시간만큼 걸릴 수 있으므로 읽기를 막지 않도록 별도 고루틴에서 처리
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			if err := s.h.manager.Stop(msg.ProcessID); err != nil {
+				s.fail(msg, "Process not found")
+				return
+			}
+			s.ack(msg, nil)
+		}()
+
+	case wsInput:
+		// 세션을 이어가는 새 프로세스를 실행하고 자동으로 구독
+		if !s.principal.Has(ScopeRun) {
+			s.fail(msg, "API key lacks the 'run' scope")
+			return
+		}
+		if !s.allow(RouteContinue, msg) {
+			return
+		}
+		if err := binding.Validator.ValidateStruct(msg.ContinueRequest); err != nil {
+			s.fail(msg, "Invalid request: "+err.Error())
+			return
+		}
+		spec, reqErr := s.h.continueSpec(s.principal, msg.ProcessID, msg.ContinueRequest)
+		if reqErr != nil {
+			s.fail(msg, reqErr.Error())
+			return
+		}
+
+		process, reqErr := s.h.createProcess(spec)
+		if reqErr != nil {
+			s.fail(msg, reqErr.Error())
+			return
+		}
+		s.subscribe(process, 0)
+		s.ack(msg, s.h.acceptedResponse(process))
+
+	default:
+		s.fail(msg, "Unknown message type: "+msg.Type)
+	}
+}
+
+// subscribe는 프로세스 이벤트를 offset부터 재생한 뒤 실시간으로 전달하는 고루틴을 시작합니다
+// 이미 구독 중인 프로세스는 기존 구독을 해제하고 다시 구독합니다
+func (s *wsSession) subscribe(process *runner.Process, offset int) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	subscription := &wsSubscription{cancel: cancel}
+
+	s.mu.Lock()
+	if previous, ok := s.subscriptions[process.ID]; ok {
+		previous.cancel()
+	}
+	s.subscriptions[process.ID] = subscription
+	s.mu.Unlock()
+
+	sub := process.Subscribe(uuid.New().String(), runner.SubscribeOptions{
+		Backpressure: s.backpressure,
+		BlockTimeout: s.h.config.Stream.BlockTimeout,
+		BufferSize:   s.h.config.Stream.SubscriberBuffer,
+	})
+
+	subscribers := metrics.Subscribers.WithLabelValues(transportWebSocket)
+	subscribers.Inc()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer subscribers.Dec()
+		defer sub.Close()
+		defer s.unsubscribed(process.ID, subscription)
+
+		forward := func(event runner.Event) bool {
+			return s.writeContext(ctx, WSServerMessage{Type: wsEvent, ProcessID: process.ID, Event: &event})
+		}
+
+		err := replayEvents(process, offset, sub.Next, func(events []runner.Event) error {
+			for _, event := range events {
+				if !forward(event) {
+					return context.Canceled
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				s.h.logger.Warn().
+					Str("processId", process.ID).
+					Err(err).
+					Msg("Failed to replay events")
+			}
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-sub.Events:
+				if !ok {
+					if sub.Disconnected() {
+						// disconnect 정책: 이 프로세스의 구독만 끊고 클라이언트가 since로 다시 구독
+						s.writeContext(ctx, WSServerMessage{
+							Type:      wsError,
+							ProcessID: process.ID,
+							Error:     "Subscriber fell behind and was disconnected",
+						})
+					}
+					return
+				}
+				if !forward(event) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// unsubscribed는 종료된 구독을 목록에서 제거합니다 (그 사이 다시 구독한 경우는 유지)
+// 서버 종료 중 마지막 구독이 끝나면 세션을 닫습니다
+func (s *wsSession) unsubscribed(processID string, subscription *wsSubscription) {
+	subscription.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subscriptions[processID] == subscription {
+		delete(s.subscriptions, processID)
+	}
+	if s.closing && len(s.subscriptions) == 0 {
+		s.cancel()
+	}
+}
+
+// closeOnShutdown은 서버가 종료되면 구독 중인 프로세스의 남은 이벤트를 보낸 뒤 세션을 닫습니다
+// 서버는 모든 프로세스를 종료한 뒤 신호를 보내므로 남은 구독도 곧 끝납니다
+func (s *wsSession) closeOnShutdown() {
+	select {
+	case <-s.h.closing:
+		s.mu.Lock()
+		s.closing = true
+		idle := len(s.subscriptions) == 0
+		s.mu.Unlock()
+
+		if idle {
+			s.cancel()
+		}
+	case <-s.ctx.Done():
+	}
+}
+
+// writeLoop는 전송 대기 중인 메시지를 순서대로 씁니다
+func (s *wsSession) writeLoop() {
+	for {
+		select {
+		case msg := <-s.send:
+			if !s.writeMessage(msg) {
+				return
+			}
+		case <-s.ctx.Done():
+			// 이미 대기열에 들어간 메시지는 모두 보낸 뒤 닫음
+			for len(s.send) > 0 {
+				if !s.writeMessage(<-s.send) {
+					return
+				}
+			}
+			s.close()
+			return
+		}
+	}
+}
+
+// writeMessage는 메시지 하나를 씁니다
+// 실패하면 세션을 취소하고 연결을 닫아 읽기 루프를 깨웁니다
+func (s *wsSession) writeMessage(msg WSServerMessage) bool {
+	s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if err := s.conn.WriteJSON(msg); err != nil {
+		s.h.logger.Debug().
+			Err(err).
+			Msg("WebSocket write failed")
+		s.cancel()
+		s.conn.Close()
+		return false
+	}
+	return true
+}
+
+// close는 close 프레임을 보내고 클라이언트의 응답을 wsWriteTimeout까지만 기다립니다
+func (s *wsSession) close() {
+	s.mu.Lock()
+	code, text := websocket.CloseNormalClosure, ""
+	if s.closing {
+		code, text = websocket.CloseGoingAway, "server shutting down"
+	}
+	s.mu.Unlock()
+
+	deadline := time.Now().Add(wsWriteTimeout)
+	s.conn.SetWriteDeadline(deadline)
+	s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, text))
+	s.conn.SetReadDeadline(deadline)
+}
+
+// write는 세션이 유지되는 동안 메시지를 전송 대기열에 넣습니다
+func (s *wsSession) write(msg WSServerMessage) bool {
+	return s.writeContext(s.ctx, msg)
+}
+
+// writeContext는 ctx가 취소되기 전까지 메시지를 전송 대기열에 넣습니다
+func (s *wsSession) writeContext(ctx context.Context, msg WSServerMessage) bool {
+	select {
+	case s.send <- msg:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// ack는 제어 메시지 처리 완료를 알립니다
+func (s *wsSession) ack(msg WSClientMessage, result gin.H) {
+	s.write(WSServerMessage{Type: wsAck, ID: msg.ID, ProcessID: msg.ProcessID, Result: result})
+}
+
+// allow는 제어 메시지에 HTTP 라우트와 같은 클라이언트별 속도 제한을 적용합니다
+// 거부하면 다음 토큰까지 남은 시간을 담은 error 메시지를 보냅니다
+func (s *wsSession) allow(route string, msg WSClientMessage) bool {
+	limiter, ok := s.h.limiters[route]
+	if !ok {
+		return true
+	}
+
+	decision := limiter.Allow(s.client, time.Now())
+	if decision.allowed {
+		return true
+	}
+
+	retryAfter := ceilSeconds(decision.retryAfter)
+	s.h.logger.Debug().
+		Str("route", route).
+		Str("client", s.client).
+		Int("retryAfter", retryAfter).
+		Msg("Rate limit exceeded")
+	metrics.RateLimited.WithLabelValues(route).Inc()
+	s.fail(msg, fmt.Sprintf("Rate limit exceeded: retry after %d seconds", retryAfter))
+	return false
+}
+
+// fail은 제어 메시지 처리 실패를 알립니다
+func (s *wsSession) fail(msg WSClientMessage, message string) {
+	s.write(WSServerMessage{Type: wsError, ID: msg.ID, ProcessID: msg.ProcessID, Error: message})
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -12,35 +13,194 @@ type Config struct {
 	Server     ServerConfig     `mapstructure:"server"`
 	Process    ProcessConfig    `mapstructure:"process"`
 	Connectors ConnectorsConfig `mapstructure:"connectors"`
+	Probe      ProbeConfig      `mapstructure:"probe"`
+	Store      StoreConfig      `mapstructure:"store"`
+	Stream     StreamConfig     `mapstructure:"stream"`
+	Auth       AuthConfig       `mapstructure:"auth"`
+	Tenants    TenantsConfig    `mapstructure:"tenants"`
+	RateLimit  RateLimitConfig  `mapstructure:"rateLimit"`
+	Metrics    MetricsConfig    `mapstructure:"metrics"`
 	Logging    LoggingConfig    `mapstructure:"logging"`
 }
 
 // ServerConfig는 HTTP 서버 설정을 포함합니다
 type ServerConfig struct {
-	Port         int           `mapstructure:"port"`
-	Host         string        `mapstructure:"host"`
-	ReadTimeout  time.Duration `mapstructure:"readTimeout"`
-	WriteTimeout time.Duration `mapstructure:"writeTimeout"`
+	Port         int            `mapstructure:"port"`
+	Host         string         `mapstructure:"host"`
+	ReadTimeout  time.Duration  `mapstructure:"readTimeout"`
+	WriteTimeout time.Duration  `mapstructure:"writeTimeout"`
+	Shutdown     ShutdownConfig `mapstructure:"shutdown"`
+
+	// TrustedProxies는 X-Forwarded-For/X-Real-IP를 신뢰할 프록시 주소(IP 또는 CIDR)입니다
+	// 비어있으면 헤더를 무시하고 연결의 원격 주소를 클라이언트 IP로 사용합니다
+	TrustedProxies []string `mapstructure:"trustedProxies"`
+}
+
+// ShutdownConfig는 서버 종료 시 실행 중인 프로세스 처리 설정을 포함합니다
+type ShutdownConfig struct {
+	Mode         string        `mapstructure:"mode"`         // drain: 실행 중인 프로세스를 drainTimeout까지 기다린 뒤 중지, stop: 즉시 중지
+	DrainTimeout time.Duration `mapstructure:"drainTimeout"` // drain일 때 최대 대기 시간
 }
 
 // ProcessConfig는 프로세스 실행 설정을 포함합니다
 type ProcessConfig struct {
-	DefaultTimeout time.Duration `mapstructure:"defaultTimeout"`
-	MaxConcurrent  int           `mapstructure:"maxConcurrent"`
-	CleanupDelay   time.Duration `mapstructure:"cleanupDelay"`
-	BufferSize     int           `mapstructure:"bufferSize"`
+	DefaultTimeout  time.Duration   `mapstructure:"defaultTimeout"`
+	MaxTimeout      time.Duration   `mapstructure:"maxTimeout"`      // 요청/커넥터 타임아웃의 상한 (0이면 제한 없음)
+	IdleTimeout     time.Duration   `mapstructure:"idleTimeout"`     // 이벤트 없이 대기할 최대 시간 (0이면 비활성화)
+	StopGracePeriod time.Duration   `mapstructure:"stopGracePeriod"` // SIGTERM 후 SIGKILL까지 대기 시간 (0이면 즉시 SIGKILL)
+	MaxConcurrent   int             `mapstructure:"maxConcurrent"`
+	MaxQueue        int             `mapstructure:"maxQueue"` // maxConcurrent 초과 시 대기할 수 있는 최대 요청 수 (0이면 즉시 거부)
+	PriorityWeights PriorityWeights `mapstructure:"priorityWeights"`
+	CleanupDelay    time.Duration   `mapstructure:"cleanupDelay"`
+	BufferSize      int             `mapstructure:"bufferSize"`
+	StderrTailLines int             `mapstructure:"stderrTailLines"` // 실패 시 Result.Error에 포함할 stderr 마지막 라인 수
+}
+
+// PriorityWeights는 대기열에서 우선순위 클래스별로 슬롯을 배정하는 비율입니다
+// 예: interactive 3, batch 1이면 두 클래스가 모두 대기 중일 때 4번 중 3번은 interactive를 시작합니다
+type PriorityWeights struct {
+	Interactive int `mapstructure:"interactive"`
+	Batch       int `mapstructure:"batch"`
 }
 
 // ConnectorConfig는 단일 커넥터의 설정을 포함합니다
 type ConnectorConfig struct {
-	Command   string   `mapstructure:"command"`
-	Args      []string `mapstructure:"args"`
-	Available bool     `mapstructure:"available"`
+	Type          string         `mapstructure:"type"` // claude, gemini, codex, aider, generic (비어있으면 이름으로 결정)
+	Command       string         `mapstructure:"command"`
+	Args          []string       `mapstructure:"args"`
+	Available     bool           `mapstructure:"available"`
+	VersionArgs   []string       `mapstructure:"versionArgs"`   // 가용성 검사에 사용할 인자 (기본값: --version)
+	Timeout       time.Duration  `mapstructure:"timeout"`       // 커넥터 기본 타임아웃 (0이면 process.defaultTimeout)
+	IdleTimeout   time.Duration  `mapstructure:"idleTimeout"`   // 커넥터 기본 유휴 타임아웃 (0이면 process.idleTimeout)
+	MaxConcurrent int            `mapstructure:"maxConcurrent"` // 이 커넥터의 최대 동시 실행 수 (0이면 전역 제한만 적용)
+	Prompt        PromptConfig   `mapstructure:"prompt"`
+	Output        OutputConfig   `mapstructure:"output"`
+	Options       []OptionConfig `mapstructure:"options"` // generic 커넥터의 요청 옵션 플래그
+}
+
+// OptionConfig는 요청 옵션을 CLI 플래그에 매핑합니다
+type OptionConfig struct {
+	Name string `mapstructure:"name"` // model, systemPrompt, allowedTools, maxTurns, resume
+	Flag string `mapstructure:"flag"` // 예: --model
+}
+
+// PromptConfig는 프롬프트를 CLI에 전달하는 방식을 정의합니다
+type PromptConfig struct {
+	Mode string `mapstructure:"mode"` // flag, positional, stdin (내장 커넥터는 flag, stdin)
+	Flag string `mapstructure:"flag"` // mode가 flag일 때 프롬프트 앞에 붙는 플래그 (예: -p)
+}
+
+// OutputConfig는 CLI 출력을 이벤트로 해석하는 방식을 정의합니다
+type OutputConfig struct {
+	Format      string     `mapstructure:"format"`      // ndjson, text, json
+	DefaultType string     `mapstructure:"defaultType"` // 규칙에 맞지 않을 때의 이벤트 타입
+	Rules       []TypeRule `mapstructure:"rules"`
+}
+
+// TypeRule은 출력 JSON의 필드 값을 이벤트 타입에 매핑합니다
+type TypeRule struct {
+	Field  string `mapstructure:"field"`  // 점으로 구분된 경로 (예: item.type)
+	Equals string `mapstructure:"equals"` // 비어있으면 필드가 존재하기만 하면 일치
+	Type   string `mapstructure:"type"`   // stream, result, error
+}
+
+// ConnectorsConfig는 이름별 커넥터 설정을 포함합니다
+// viper는 맵 키를 소문자로 정규화하므로 커넥터 이름은 소문자로 취급됩니다
+type ConnectorsConfig map[string]ConnectorConfig
+
+// ProbeConfig는 커넥터 가용성 검사 설정을 포함합니다
+type ProbeConfig struct {
+	Interval time.Duration `mapstructure:"interval"` // 0이면 시작 시에만 검사
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// StoreConfig는 프로세스 기록을 영구 저장하는 설정을 포함합니다
+type StoreConfig struct {
+	Path      string         `mapstructure:"path"`      // bbolt 데이터베이스 파일 경로 (비어있으면 메모리에만 보관)
+	Retention time.Duration  `mapstructure:"retention"` // 종료된 기록을 보관할 기간 (0이면 삭제하지 않음)
+	Events    EventLogConfig `mapstructure:"events"`
+}
+
+// EventLogConfig는 프로세스별 세그먼트 이벤트 로그 설정을 포함합니다
+type EventLogConfig struct {
+	Dir          string        `mapstructure:"dir"`          // 로그 디렉토리 (비어있으면 링 버퍼에 남은 이벤트만 재생 가능)
+	SegmentSize  int64         `mapstructure:"segmentSize"`  // 세그먼트 파일 하나의 최대 크기 (바이트)
+	Retention    time.Duration `mapstructure:"retention"`    // 마지막 기록 후 로그를 보관할 기간 (0이면 store.retention)
+	MaxTotalSize int64         `mapstructure:"maxTotalSize"` // 전체 로그 크기 상한, 넘으면 오래된 로그부터 삭제 (0이면 제한 없음)
+}
+
+// StreamConfig는 이벤트 스트림 구독자 설정을 포함합니다
+type StreamConfig struct {
+	Backpressure      string        `mapstructure:"backpressure"`      // 구독 버퍼가 가득 찼을 때: block, drop, disconnect
+	BlockTimeout      time.Duration `mapstructure:"blockTimeout"`      // block일 때 이벤트 하나당 최대 대기 시간
+	SubscriberBuffer  int           `mapstructure:"subscriberBuffer"`  // 구독자별 이벤트 버퍼 크기
+	AllowedOrigins    []string      `mapstructure:"allowedOrigins"`    // WebSocket 연결을 허용할 Origin (비어있으면 같은 호스트만, "*"이면 모두)
+	HeartbeatInterval time.Duration `mapstructure:"heartbeatInterval"` // SSE ping 주석 전송 주기 (0이면 보내지 않음)
+	Retry             time.Duration `mapstructure:"retry"`             // SSE retry 힌트, 클라이언트의 재연결 대기 시간 (0이면 보내지 않음)
+}
+
+// AuthConfig는 API 키 인증 설정을 포함합니다
+type AuthConfig struct {
+	Keys []APIKeyConfig `mapstructure:"keys"` // 비어있으면 인증하지 않음
+}
+
+// APIKeyConfig는 하나의 API 키를 나타냅니다
+// 키 원문은 저장하지 않고 SHA-256 해시만 보관합니다 (예: printf '%s' "$KEY" | sha256sum)
+type APIKeyConfig struct {
+	Name   string   `mapstructure:"name"`   // 프로세스 소유자로 기록되는 이름
+	Hash   string   `mapstructure:"hash"`   // 키의 SHA-256 해시 (hex, "sha256:" 접두사 허용)
+	Scopes []string `mapstructure:"scopes"` // run, read, stop, admin
+	Tenant string   `mapstructure:"tenant"` // 소속 테넌트 (비어있으면 키 이름)
+}
+
+// TenantName은 키가 속한 테넌트 이름을 반환합니다
+// quotas의 키와 같이 소문자로 정규화하므로 테넌트 이름은 대소문자를 구분하지 않습니다
+func (k APIKeyConfig) TenantName() string {
+	if k.Tenant == "" {
+		return strings.ToLower(k.Name)
+	}
+	return strings.ToLower(k.Tenant)
+}
+
+// TenantsConfig는 테넌트별 사용량 제한을 포함합니다
+type TenantsConfig struct {
+	Default TenantQuota            `mapstructure:"default"` // 별도 설정이 없는 테넌트에 적용
+	Quotas  map[string]TenantQuota `mapstructure:"quotas"`  // 테넌트 이름 → 제한 (viper가 소문자로 정규화)
 }
 
-// ConnectorsConfig는 모든 커녅터 설정을 포함합니다
-type ConnectorsConfig struct {
-	Claude ConnectorConfig `mapstructure:"claude"`
+// TenantQuota는 한 테넌트의 사용량 제한입니다 (0이면 제한 없음)
+// 일일 제한은 서버 로컬 시간 기준으로 자정에 초기화됩니다
+type TenantQuota struct {
+	MaxConcurrent   int           `mapstructure:"maxConcurrent"`   // 동시 실행 수 (초과하면 대기열에서 대기)
+	MaxDailyRuns    int           `mapstructure:"maxDailyRuns"`    // 하루 실행 요청 수
+	MaxDailyRuntime time.Duration `mapstructure:"maxDailyRuntime"` // 하루 누적 실행 시간
+}
+
+// RateLimitConfig는 라우트별 요청 속도 제한을 포함합니다
+// 클라이언트(API 키, 인증을 사용하지 않으면 IP)마다 토큰 버킷을 따로 둡니다
+type RateLimitConfig struct {
+	Routes map[string]RateLimitRule `mapstructure:"routes"` // 라우트 이름 → 제한 (없는 라우트는 제한 없음)
+}
+
+// RateLimitRule은 한 라우트의 토큰 버킷 설정입니다
+type RateLimitRule struct {
+	Requests int           `mapstructure:"requests"` // per 동안 보충되는 요청 수
+	Per      time.Duration `mapstructure:"per"`
+	Burst    int           `mapstructure:"burst"` // 연속으로 허용하는 최대 요청 수 (0이면 requests)
+}
+
+// MetricsConfig는 Prometheus 메트릭 설정을 포함합니다
+type MetricsConfig struct {
+	Enabled bool `mapstructure:"enabled"` // GET /metrics 노출 여부
+}
+
+// Quota는 테넌트에 적용할 사용량 제한을 반환합니다
+// quotas의 키는 소문자로 정규화되므로 대소문자를 구분하지 않고 찾습니다
+func (c TenantsConfig) Quota(tenant string) TenantQuota {
+	if quota, ok := c.Quotas[strings.ToLower(tenant)]; ok {
+		return quota
+	}
+	return c.Default
 }
 
 // LoggingConfig는 로깅 설정을 포함합니다
@@ -81,9 +241,44 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// 커넥터별 기본값 적용 (맵 항목에는 SetDefault가 적용되지 않음)
+	for name, conn := range cfg.Connectors {
+		if !v.IsSet("connectors." + name + ".available") {
+			conn.Available = true
+		}
+		cfg.Connectors[name] = conn
+	}
+
 	return &cfg, nil
 }
 
+// ResolveTimeouts는 요청 값, 커넥터 기본값, 전역 기본값 순서로 타임아웃을 결정합니다
+// 0 이하의 요청 값은 지정되지 않은 것으로 취급하며 결과는 process.maxTimeout으로 제한됩니다
+func (c *Config) ResolveTimeouts(connector string, timeout, idleTimeout time.Duration) (time.Duration, time.Duration) {
+	conn := c.Connectors[connector]
+
+	if timeout <= 0 {
+		timeout = conn.Timeout
+	}
+	if timeout <= 0 {
+		timeout = c.Process.DefaultTimeout
+	}
+
+	if idleTimeout <= 0 {
+		idleTimeout = conn.IdleTimeout
+	}
+	if idleTimeout <= 0 {
+		idleTimeout = c.Process.IdleTimeout
+	}
+
+	if limit := c.Process.MaxTimeout; limit > 0 {
+		timeout = min(timeout, limit)
+		idleTimeout = min(idleTimeout, limit)
+	}
+
+	return timeout, idleTimeout
+}
+
 // setDefaults는 합리적인 기본값을 구성합니다
 func setDefaults(v *viper.Viper) {
 	// 서버 기본값
@@ -91,17 +286,71 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.host", "localhost")
 	v.SetDefault("server.readTimeout", 30*time.Second)
 	v.SetDefault("server.writeTimeout", 30*time.Second)
+	v.SetDefault("server.shutdown.mode", "drain")
+	v.SetDefault("server.shutdown.drainTimeout", 30*time.Second)
+	v.SetDefault("server.trustedProxies", []string{})
 
 	// 프로세스 기본값
 	v.SetDefault("process.defaultTimeout", 5*time.Minute)
+	v.SetDefault("process.maxTimeout", 2*time.Hour)
+	v.SetDefault("process.idleTimeout", 0)
+	v.SetDefault("process.stopGracePeriod", 10*time.Second)
 	v.SetDefault("process.maxConcurrent", 10)
+	v.SetDefault("process.maxQueue", 100)
+	v.SetDefault("process.priorityWeights.interactive", 3)
+	v.SetDefault("process.priorityWeights.batch", 1)
 	v.SetDefault("process.cleanupDelay", 5*time.Second)
 	v.SetDefault("process.bufferSize", 8192)
+	v.SetDefault("process.stderrTailLines", 50)
+
+	// 저장소 기본값
+	v.SetDefault("store.path", "data/cli-runner.db")
+	v.SetDefault("store.retention", 30*24*time.Hour)
+	v.SetDefault("store.events.dir", "data/events")
+	v.SetDefault("store.events.segmentSize", 8<<20)
+	v.SetDefault("store.events.retention", 0)
+	v.SetDefault("store.events.maxTotalSize", 1<<30)
+
+	// 스트림 기본값
+	v.SetDefault("stream.backpressure", "drop")
+	v.SetDefault("stream.blockTimeout", 100*time.Millisecond)
+	v.SetDefault("stream.subscriberBuffer", 256)
+	v.SetDefault("stream.allowedOrigins", []string{})
+	v.SetDefault("stream.heartbeatInterval", 15*time.Second)
+	v.SetDefault("stream.retry", 3*time.Second)
+
+	// 요청 속도 제한 기본값 (프로세스를 생성하는 라우트)
+	v.SetDefault("rateLimit.routes.run.requests", 60)
+	v.SetDefault("rateLimit.routes.run.per", time.Minute)
+	v.SetDefault("rateLimit.routes.run.burst", 10)
+	v.SetDefault("rateLimit.routes.continue.requests", 60)
+	v.SetDefault("rateLimit.routes.continue.per", time.Minute)
+	v.SetDefault("rateLimit.routes.continue.burst", 10)
 
 	// 커녅터 기본값 - Claude
 	v.SetDefault("connectors.claude.command", "claude")
 	v.SetDefault("connectors.claude.args", []string{})
 	v.SetDefault("connectors.claude.available", true)
+	v.SetDefault("connectors.claude.prompt.mode", "stdin")
+
+	// 커녅터 기본값 - Gemini
+	v.SetDefault("connectors.gemini.command", "gemini")
+	v.SetDefault("connectors.gemini.args", []string{"--output-format", "stream-json"})
+
+	// 커녅터 기본값 - Codex
+	v.SetDefault("connectors.codex.command", "codex")
+	v.SetDefault("connectors.codex.args", []string{"exec", "--json"})
+
+	// 커녅터 기본값 - Aider
+	v.SetDefault("connectors.aider.command", "aider")
+	v.SetDefault("connectors.aider.args", []string{"--no-pretty", "--yes-always"})
+
+	// 메트릭 기본값
+	v.SetDefault("metrics.enabled", true)
+
+	// 커넥터 가용성 검사 기본값
+	v.SetDefault("probe.interval", 5*time.Minute)
+	v.SetDefault("probe.timeout", 10*time.Second)
 
 	// 로깅 기본값
 	v.SetDefault("logging.level", "info")
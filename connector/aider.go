@@ -0,0 +1,197 @@
+package connector
+
+import (
+	"encoding/json"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cli-runner/config"
+	"cli-runner/runner"
+)
+
+var (
+	// aiderCommitPattern은 "Commit abc1234 feat: message" 형식의 커밋 요약과 일치합니다
+	aiderCommitPattern = regexp.MustCompile(`^Commit ([0-9a-f]{7,40}) (.+)$`)
+	// aiderEditPattern은 "Applied edit to path/file.go" 형식의 편집 요약과 일치합니다
+	aiderEditPattern = regexp.MustCompile(`^Applied edit to (.+)$`)
+	// aiderTokensPattern은 "Tokens: 2.3k sent, 120 received. Cost: $0.01 message, ..." 형식의 사용량 요약과 일치합니다
+	aiderTokensPattern = regexp.MustCompile(`^Tokens: ([0-9.,]+k?) sent(?:, [0-9.,]+k? cache \w+)*, ([0-9.,]+k?) received\.(?: Cost: \$([0-9.]+) message)?`)
+	// ansiPattern은 터미널 색상/커서 제어 시퀀스와 일치합니다
+	ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+)
+
+// aiderResultLines는 result 이벤트의 텍스트로 보관하는 최대 라인 수입니다 (넘으면 마지막 라인만 유지)
+const aiderResultLines = 1000
+
+// aiderOptionFlags는 Aider CLI의 옵션 플래그입니다
+var aiderOptionFlags = map[string]string{
+	runner.OptionModel: "--model",
+}
+
+// AiderConnector는 Aider CLI를 위한 Connector를 구현합니다
+// Aider는 JSON이 아닌 사람이 읽는 텍스트를 출력하므로 라인을 text 이벤트로 감쌉니다
+type AiderConnector struct {
+	name   string
+	config config.ConnectorConfig
+}
+
+// NewAiderConnector는 새로운 Aider 커넥터를 생성합니다
+func NewAiderConnector(name string, cfg config.ConnectorConfig) *AiderConnector {
+	return &AiderConnector{
+		name:   name,
+		config: cfg,
+	}
+}
+
+// Name은 커넥터 이름을 반환합니다
+func (c *AiderConnector) Name() string {
+	return c.name
+}
+
+// IsAvailable은 커넥터를 사용할 수 있는지 여부를 반환합니다
+func (c *AiderConnector) IsAvailable() bool {
+	return c.config.Available
+}
+
+// SupportedOptions는 지원하는 옵션 이름을 반환합니다
+func (c *AiderConnector) SupportedOptions() []string {
+	return optionNames(aiderOptionFlags)
+}
+
+// BuildCommand는 실행할 명령을 구축합니다
+func (c *AiderConnector) BuildCommand(prompt string, opts runner.Options) *exec.Cmd {
+	// 구축: aider [설정의 args] [옵션 플래그] --message "prompt"
+	args := append([]string{}, c.config.Args...)
+	args = append(args, optionFlags(opts, aiderOptionFlags)...)
+	args = append(args, "--message", prompt)
+	return exec.Command(c.config.Command, args...)
+}
+
+// ParseLine은 단일 라인을 파싱합니다 (최종 result 생성에는 NewParser가 사용됨)
+func (c *AiderConnector) ParseLine(line string) (*runner.Event, error) {
+	return c.NewParser().ParseLine(line)
+}
+
+// NewParser는 실행마다 출력과 편집/커밋 요약을 누적하는 파서를 생성합니다
+func (c *AiderConnector) NewParser() runner.OutputParser {
+	return &aiderParser{lines: runner.NewRingBuffer[string](aiderResultLines)}
+}
+
+// aiderCommit은 Aider가 생성한 커밋 요약입니다
+type aiderCommit struct {
+	Hash    string `json:"hash"`
+	Message string `json:"message"`
+}
+
+// aiderParser는 Aider 텍스트 출력을 stream 이벤트로 변환하고 종료 시 result를 합성합니다
+type aiderParser struct {
+	lines   *runner.RingBuffer[string] // 일반 텍스트 라인 (최근 aiderResultLines개)
+	edits   []string
+	commits []aiderCommit
+	usage   *runner.Usage
+}
+
+// ParseLine은 텍스트 라인을 {"text": ...} stream 이벤트로 변환합니다
+// 커밋/편집 요약 라인에는 kind와 상세 필드가 추가됩니다
+func (p *aiderParser) ParseLine(line string) (*runner.Event, error) {
+	line = strings.TrimRight(ansiPattern.ReplaceAllString(line, ""), " \t")
+	if strings.TrimSpace(line) == "" {
+		return nil, nil
+	}
+
+	payload := map[string]string{"text": line}
+	var normalized runner.Normalized
+
+	if m := aiderCommitPattern.FindStringSubmatch(line); m != nil {
+		p.commits = append(p.commits, aiderCommit{Hash: m[1], Message: m[2]})
+		payload["kind"] = "commit"
+		payload["hash"] = m[1]
+		payload["message"] = m[2]
+		normalized = runner.Normalized{
+			Kind: runner.KindToolResult,
+			Tool: &runner.ToolCall{ID: m[1], Name: "commit", Output: m[2]},
+		}
+	} else if m := aiderEditPattern.FindStringSubmatch(line); m != nil {
+		p.edits = append(p.edits, m[1])
+		payload["kind"] = "edit"
+		payload["file"] = m[1]
+		normalized = runner.Normalized{
+			Kind: runner.KindToolResult,
+			Tool: &runner.ToolCall{Name: "edit", Output: m[1]},
+		}
+	} else if m := aiderTokensPattern.FindStringSubmatch(line); m != nil {
+		p.usage = &runner.Usage{
+			InputTokens:  parseAiderTokens(m[1]),
+			OutputTokens: parseAiderTokens(m[2]),
+		}
+		if m[3] != "" {
+			p.usage.CostUSD, _ = strconv.ParseFloat(m[3], 64)
+		}
+		payload["kind"] = "usage"
+		normalized = runner.Normalized{Kind: runner.KindUsage, Usage: p.usage}
+	} else {
+		p.lines.Push(line)
+		normalized = runner.TextDelta(line + "\n")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &runner.Event{Type: "stream", Data: data, Normalized: []runner.Normalized{normalized}}, nil
+}
+
+// parseAiderTokens는 "2.3k", "1,024" 형식의 토큰 수를 정수로 변환합니다
+func parseAiderTokens(value string) int64 {
+	value = strings.ReplaceAll(value, ",", "")
+	multiplier := 1.0
+	if strings.HasSuffix(value, "k") {
+		value = strings.TrimSuffix(value, "k")
+		multiplier = 1000
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(n * multiplier)
+}
+
+// Finish는 정상 종료된 실행의 누적된 텍스트와 요약으로 result 이벤트를 합성합니다
+// 중지되거나 실패한 실행은 완료된 결과가 아니므로 합성하지 않습니다
+func (p *aiderParser) Finish(success bool) ([]*runner.Event, error) {
+	if !success || p.lines.Len() == 0 && len(p.edits) == 0 && len(p.commits) == 0 {
+		return nil, nil
+	}
+
+	edits := p.edits
+	if edits == nil {
+		edits = []string{}
+	}
+	commits := p.commits
+	if commits == nil {
+		commits = []aiderCommit{}
+	}
+
+	result := strings.Join(p.lines.ToSlice(), "\n")
+	data, err := json.Marshal(map[string]interface{}{
+		"type":    "result",
+		"result":  result,
+		"edits":   edits,
+		"commits": commits,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized []runner.Normalized
+	if p.usage != nil {
+		normalized = append(normalized, runner.Normalized{Kind: runner.KindUsage, Usage: p.usage})
+	}
+	normalized = append(normalized, runner.Normalized{Kind: runner.KindResult, Text: result})
+
+	return []*runner.Event{{Type: "result", Data: data, Normalized: normalized}}, nil
+}
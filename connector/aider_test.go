@@ -0,0 +1,54 @@
+package connector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"cli-runner/config"
+)
+
+// 결과 텍스트는 마지막 aiderResultLines개 라인만 보관하고, 편집과 커밋 요약은 그대로 유지
+func TestAiderParserCapsResultLines(t *testing.T) {
+	parser := NewAiderConnector("aider", config.ConnectorConfig{}).NewParser()
+
+	total := aiderResultLines + 5
+	for i := 1; i <= total; i++ {
+		if _, err := parser.ParseLine(fmt.Sprintf("line %d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, line := range []string{"Applied edit to main.go", "Commit abc1234 feat: add main"} {
+		if _, err := parser.ParseLine(line); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := parser.Finish(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Finish returned %d events, want 1 result", len(events))
+	}
+
+	var result struct {
+		Result  string
+		Edits   []string
+		Commits []aiderCommit
+	}
+	if err := json.Unmarshal(events[0].Data, &result); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(result.Result, "\n")
+	if len(lines) != aiderResultLines {
+		t.Errorf("result has %d lines, want %d", len(lines), aiderResultLines)
+	}
+	if first, last := lines[0], lines[len(lines)-1]; first != "line 6" || last != fmt.Sprintf("line %d", total) {
+		t.Errorf("result spans %q to %q, want line 6 to line %d", first, last, total)
+	}
+	if len(result.Edits) != 1 || len(result.Commits) != 1 {
+		t.Errorf("edits = %v, commits = %v, want one of each", result.Edits, result.Commits)
+	}
+}
@@ -9,21 +9,32 @@ import (
 	"cli-runner/runner"
 )
 
+// claudeOptionFlags는 Claude CLI의 옵션 플래그입니다
+var claudeOptionFlags = map[string]string{
+	runner.OptionModel:        "--model",
+	runner.OptionSystemPrompt: "--append-system-prompt",
+	runner.OptionAllowedTools: "--allowedTools",
+	runner.OptionMaxTurns:     "--max-turns",
+	runner.OptionResume:       "--resume",
+}
+
 // ClaudeConnector는 Claude CLI를 위한 Connector를 구현합니다
 type ClaudeConnector struct {
+	name   string
 	config config.ConnectorConfig
 }
 
 // NewClaudeConnector는 새로운 Claude 커녅터를 생성합니다
-func NewClaudeConnector(cfg config.ConnectorConfig) *ClaudeConnector {
+func NewClaudeConnector(name string, cfg config.ConnectorConfig) *ClaudeConnector {
 	return &ClaudeConnector{
+		name:   name,
 		config: cfg,
 	}
 }
 
 // Name은 커녅터 이름을 반환합니다
 func (c *ClaudeConnector) Name() string {
-	return "claude"
+	return c.name
 }
 
 // IsAvailable은 커녅터를 사용할 수 있는지 여부를 반환합니다
@@ -31,10 +42,25 @@ func (c *ClaudeConnector) IsAvailable() bool {
 	return c.config.Available
 }
 
+// SupportedOptions는 지원하는 옵션 이름을 반환합니다
+func (c *ClaudeConnector) SupportedOptions() []string {
+	return optionNames(claudeOptionFlags)
+}
+
+// PromptViaStdin은 prompt.mode가 stdin인 경우 프롬프트를 stdin으로 전달합니다
+func (c *ClaudeConnector) PromptViaStdin() bool {
+	return c.config.Prompt.Mode == PromptModeStdin
+}
+
 // BuildCommand는 실행할 명령을 구축합니다
-func (c *ClaudeConnector) BuildCommand(prompt string) *exec.Cmd {
-	// 구축: claude [설정의 args] -p "prompt"
-	args := append(c.config.Args, "-p", prompt)
+func (c *ClaudeConnector) BuildCommand(prompt string, opts runner.Options) *exec.Cmd {
+	// 구축: claude [설정의 args] [옵션 플래그] -p ["prompt"]
+	args := append([]string{}, c.config.Args...)
+	args = append(args, optionFlags(opts, claudeOptionFlags)...)
+	args = append(args, "-p")
+	if !c.PromptViaStdin() {
+		args = append(args, prompt)
+	}
 	return exec.Command(c.config.Command, args...)
 }
 
@@ -65,9 +91,157 @@ func (c *ClaudeConnector) ParseLine(line string) (*runner.Event, error) {
 	data := json.RawMessage(line)
 
 	event := &runner.Event{
-		Type: eventType,
-		Data: data,
+		Type:       eventType,
+		Data:       data,
+		Normalized: normalizeClaude(data),
 	}
 
 	return event, nil
 }
+
+// claudeLine은 Claude stream-json 라인에서 정규화에 필요한 필드입니다
+type claudeLine struct {
+	Type      string `json:"type"`
+	Subtype   string `json:"subtype"`
+	SessionID string `json:"session_id"`
+	Model     string `json:"model"`
+	Message   struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+	// stream_event는 --include-partial-messages 사용 시 전달되는 부분 응답입니다
+	Event struct {
+		Type  string `json:"type"`
+		Delta struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"delta"`
+	} `json:"event"`
+	Result       string       `json:"result"`
+	IsError      bool         `json:"is_error"`
+	TotalCostUSD float64      `json:"total_cost_usd"`
+	DurationMs   int64        `json:"duration_ms"`
+	Usage        *claudeUsage `json:"usage"`
+}
+
+// claudeUsage는 result 라인의 토큰 사용량입니다
+type claudeUsage struct {
+	InputTokens          int64 `json:"input_tokens"`
+	OutputTokens         int64 `json:"output_tokens"`
+	CacheReadInputTokens int64 `json:"cache_read_input_tokens"`
+}
+
+// claudeContent는 assistant/user 메시지의 content 블록입니다
+type claudeContent struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	Content   json.RawMessage `json:"content"`
+	IsError   bool            `json:"is_error"`
+}
+
+// normalizeClaude는 Claude stream-json 라인을 공통 이벤트로 변환합니다
+// 알 수 없는 형식이면 nil을 반환합니다 (원본 데이터는 그대로 전달됨)
+func normalizeClaude(data json.RawMessage) []runner.Normalized {
+	var parsed claudeLine
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil
+	}
+
+	switch parsed.Type {
+	case "system":
+		if parsed.Subtype == "init" {
+			return []runner.Normalized{{Kind: runner.KindSystem, SessionID: parsed.SessionID, Model: parsed.Model}}
+		}
+
+	case "stream_event":
+		if parsed.Event.Type == "content_block_delta" && parsed.Event.Delta.Type == "text_delta" {
+			return []runner.Normalized{runner.TextDelta(parsed.Event.Delta.Text)}
+		}
+
+	case "assistant", "user":
+		var blocks []claudeContent
+		if err := json.Unmarshal(parsed.Message.Content, &blocks); err != nil {
+			// 문자열 content (사용자 프롬프트 재전송 등)는 렌더링 대상이 아님
+			return nil
+		}
+
+		var normalized []runner.Normalized
+		for _, block := range blocks {
+			switch block.Type {
+			case "text":
+				normalized = append(normalized, runner.Normalized{Kind: runner.KindText, Text: block.Text})
+			case "tool_use":
+				normalized = append(normalized, runner.Normalized{
+					Kind: runner.KindToolUse,
+					Tool: &runner.ToolCall{ID: block.ID, Name: block.Name, Input: block.Input},
+				})
+			case "tool_result":
+				normalized = append(normalized, runner.Normalized{
+					Kind:    runner.KindToolResult,
+					Tool:    &runner.ToolCall{ID: block.ToolUseID, Output: claudeContentText(block.Content)},
+					IsError: block.IsError,
+				})
+			}
+		}
+		return normalized
+
+	case "result":
+		normalized := make([]runner.Normalized, 0, 2)
+		if parsed.Usage != nil {
+			normalized = append(normalized, runner.Normalized{
+				Kind: runner.KindUsage,
+				Usage: &runner.Usage{
+					InputTokens:  parsed.Usage.InputTokens,
+					OutputTokens: parsed.Usage.OutputTokens,
+					CachedTokens: parsed.Usage.CacheReadInputTokens,
+					CostUSD:      parsed.TotalCostUSD,
+					DurationMs:   parsed.DurationMs,
+				},
+			})
+		}
+		normalized = append(normalized, runner.Normalized{
+			Kind:      runner.KindResult,
+			Text:      parsed.Result,
+			SessionID: parsed.SessionID,
+			IsError:   parsed.IsError,
+		})
+		return normalized
+	}
+
+	return nil
+}
+
+// claudeContentText는 tool_result content(문자열 또는 text 블록 배열)를 문자열로 변환합니다
+func claudeContentText(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	var blocks []claudeContent
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Type == "text" {
+			parts = append(parts, block.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// SessionID는 stream-json 이벤트의 session_id 필드를 반환합니다
+func (c *ClaudeConnector) SessionID(event runner.Event) string {
+	var payload struct {
+		SessionID string `json:"session_id"`
+	}
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		return ""
+	}
+	return payload.SessionID
+}
@@ -0,0 +1,267 @@
+package connector
+
+import (
+	"encoding/json"
+	"os/exec"
+	"strings"
+
+	"cli-runner/config"
+	"cli-runner/runner"
+)
+
+// codexOptionFlags는 Codex CLI의 옵션 플래그입니다
+var codexOptionFlags = map[string]string{
+	runner.OptionModel: "--model",
+}
+
+// CodexConnector는 OpenAI Codex CLI를 위한 Connector를 구현합니다
+// 비대화형 모드(codex exec --json)의 JSON 라인 출력을 runner 이벤트로 변환합니다
+type CodexConnector struct {
+	name   string
+	config config.ConnectorConfig
+}
+
+// NewCodexConnector는 새로운 Codex 커넥터를 생성합니다
+func NewCodexConnector(name string, cfg config.ConnectorConfig) *CodexConnector {
+	return &CodexConnector{
+		name:   name,
+		config: cfg,
+	}
+}
+
+// Name은 커넥터 이름을 반환합니다
+func (c *CodexConnector) Name() string {
+	return c.name
+}
+
+// IsAvailable은 커넥터를 사용할 수 있는지 여부를 반환합니다
+func (c *CodexConnector) IsAvailable() bool {
+	return c.config.Available
+}
+
+// SupportedOptions는 지원하는 옵션 이름을 반환합니다
+func (c *CodexConnector) SupportedOptions() []string {
+	return optionNames(codexOptionFlags)
+}
+
+// PromptViaStdin은 prompt.mode가 stdin인 경우 프롬프트를 stdin으로 전달합니다
+func (c *CodexConnector) PromptViaStdin() bool {
+	return c.config.Prompt.Mode == PromptModeStdin
+}
+
+// BuildCommand는 실행할 명령을 구축합니다
+func (c *CodexConnector) BuildCommand(prompt string, opts runner.Options) *exec.Cmd {
+	// 구축: codex [설정의 args (exec --json ...)] [옵션 플래그] "prompt"
+	// stdin 모드에서는 프롬프트 대신 "-"를 전달하여 stdin에서 읽도록 함
+	args := append([]string{}, c.config.Args...)
+	args = append(args, optionFlags(opts, codexOptionFlags)...)
+	if c.PromptViaStdin() {
+		args = append(args, "-")
+	} else {
+		args = append(args, prompt)
+	}
+	return exec.Command(c.config.Command, args...)
+}
+
+// ParseLine은 단일 라인을 파싱합니다 (최종 응답 추출에는 NewParser가 사용됨)
+func (c *CodexConnector) ParseLine(line string) (*runner.Event, error) {
+	return c.NewParser().ParseLine(line)
+}
+
+// NewParser는 실행마다 마지막 agent 메시지를 추적하는 파서를 생성합니다
+func (c *CodexConnector) NewParser() runner.OutputParser {
+	return &codexParser{}
+}
+
+// codexEvent는 Codex JSON 라인에서 필요한 필드입니다
+type codexEvent struct {
+	Type     string    `json:"type"`
+	ThreadID string    `json:"thread_id"`
+	Item     codexItem `json:"item"`
+	Usage    *struct {
+		InputTokens       int64 `json:"input_tokens"`
+		CachedInputTokens int64 `json:"cached_input_tokens"`
+		OutputTokens      int64 `json:"output_tokens"`
+	} `json:"usage"`
+	Message string `json:"message"`
+	Error   struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// codexItem은 item.started/item.completed 이벤트의 항목입니다
+type codexItem struct {
+	ID               string `json:"id"`
+	Type             string `json:"type"`
+	Text             string `json:"text"`
+	Command          string `json:"command"`
+	AggregatedOutput string `json:"aggregated_output"`
+	ExitCode         *int   `json:"exit_code"`
+	Status           string `json:"status"`
+	Server           string `json:"server"`
+	Tool             string `json:"tool"`
+	Query            string `json:"query"`
+	Message          string `json:"message"`
+	Changes          []struct {
+		Path string `json:"path"`
+		Kind string `json:"kind"`
+	} `json:"changes"`
+}
+
+// codexParser는 Codex의 thread/turn/item 이벤트를 stream/result/error 이벤트로 변환합니다
+type codexParser struct {
+	threadID     string
+	agentMessage string // 마지막으로 완료된 agent_message
+}
+
+// ParseLine은 Codex JSON 라인을 파싱합니다
+func (p *codexParser) ParseLine(line string) (*runner.Event, error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil, nil
+	}
+
+	var parsed codexEvent
+	if err := json.Unmarshal([]byte(line), &parsed); err != nil {
+		// 유효한 JSON이 아님, 건너뛰기
+		return nil, nil
+	}
+
+	event := &runner.Event{Type: "stream", Data: json.RawMessage(line)}
+
+	switch parsed.Type {
+	case "thread.started":
+		p.threadID = parsed.ThreadID
+		event.Normalized = []runner.Normalized{{Kind: runner.KindSystem, SessionID: parsed.ThreadID}}
+
+	case "item.started":
+		event.Normalized = normalizeCodexItemStarted(parsed.Item)
+
+	case "item.completed":
+		if parsed.Item.Type == "agent_message" {
+			p.agentMessage = parsed.Item.Text
+		}
+		event.Normalized = normalizeCodexItemCompleted(parsed.Item)
+
+	case "turn.completed":
+		// 턴 종료 시 마지막 agent 메시지를 최종 결과로 전달
+		data, err := p.resultData(line)
+		if err != nil {
+			return nil, err
+		}
+		event.Type = "result"
+		event.Data = data
+		if parsed.Usage != nil {
+			event.Normalized = append(event.Normalized, runner.Normalized{
+				Kind: runner.KindUsage,
+				Usage: &runner.Usage{
+					InputTokens:  parsed.Usage.InputTokens,
+					OutputTokens: parsed.Usage.OutputTokens,
+					CachedTokens: parsed.Usage.CachedInputTokens,
+				},
+			})
+		}
+		event.Normalized = append(event.Normalized, runner.Normalized{
+			Kind:      runner.KindResult,
+			Text:      p.agentMessage,
+			SessionID: p.threadID,
+		})
+
+	case "turn.failed", "error":
+		message := parsed.Message
+		if message == "" {
+			message = parsed.Error.Message
+		}
+		event.Type = "error"
+		event.Normalized = []runner.Normalized{runner.ErrorMessage(message)}
+	}
+
+	return event, nil
+}
+
+// normalizeCodexItemStarted는 시작된 도구 실행 항목을 tool_use로 변환합니다
+func normalizeCodexItemStarted(item codexItem) []runner.Normalized {
+	var input map[string]string
+	name := item.Type
+
+	switch item.Type {
+	case "command_execution":
+		input = map[string]string{"command": item.Command}
+	case "mcp_tool_call":
+		name = item.Server + "." + item.Tool
+	case "web_search":
+		input = map[string]string{"query": item.Query}
+	default:
+		return nil
+	}
+
+	tool := &runner.ToolCall{ID: item.ID, Name: name}
+	if input != nil {
+		tool.Input, _ = json.Marshal(input)
+	}
+	return []runner.Normalized{{Kind: runner.KindToolUse, Tool: tool}}
+}
+
+// normalizeCodexItemCompleted는 완료된 항목을 text/tool_result/error로 변환합니다
+func normalizeCodexItemCompleted(item codexItem) []runner.Normalized {
+	switch item.Type {
+	case "agent_message":
+		return []runner.Normalized{{Kind: runner.KindText, Text: item.Text}}
+
+	case "command_execution":
+		failed := item.Status == "failed" || (item.ExitCode != nil && *item.ExitCode != 0)
+		return []runner.Normalized{{
+			Kind:    runner.KindToolResult,
+			Tool:    &runner.ToolCall{ID: item.ID, Name: item.Type, Output: item.AggregatedOutput},
+			IsError: failed,
+		}}
+
+	case "file_change":
+		paths := make([]string, 0, len(item.Changes))
+		for _, change := range item.Changes {
+			paths = append(paths, change.Kind+" "+change.Path)
+		}
+		return []runner.Normalized{{
+			Kind:    runner.KindToolResult,
+			Tool:    &runner.ToolCall{ID: item.ID, Name: item.Type, Output: strings.Join(paths, "\n")},
+			IsError: item.Status == "failed",
+		}}
+
+	case "mcp_tool_call", "web_search":
+		name := item.Type
+		if item.Type == "mcp_tool_call" {
+			name = item.Server + "." + item.Tool
+		}
+		return []runner.Normalized{{
+			Kind:    runner.KindToolResult,
+			Tool:    &runner.ToolCall{ID: item.ID, Name: name},
+			IsError: item.Status == "failed",
+		}}
+
+	case "error":
+		return []runner.Normalized{runner.ErrorMessage(item.Message)}
+	}
+
+	return nil
+}
+
+// Finish는 남은 이벤트가 없으므로 아무것도 반환하지 않습니다
+func (p *codexParser) Finish(bool) ([]*runner.Event, error) {
+	return nil, nil
+}
+
+// resultData는 turn.completed 라인(usage 포함)에 최종 응답과 thread ID를 추가합니다
+func (p *codexParser) resultData(line string) (json.RawMessage, error) {
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &result); err != nil {
+		return nil, err
+	}
+
+	result["type"] = "result"
+	result["result"] = p.agentMessage
+	if p.threadID != "" {
+		result["thread_id"] = p.threadID
+	}
+
+	return json.Marshal(result)
+}
@@ -2,9 +2,14 @@ package connector
 
 import (
 	"errors"
+	"fmt"
 	"os/exec"
+	"slices"
+	"sort"
 	"sync"
 
+	"github.com/rs/zerolog"
+
 	"cli-runner/config"
 	"cli-runner/runner"
 )
@@ -12,26 +17,98 @@ import (
 var (
 	ErrConnectorNotFound    = errors.New("connector not found")
 	ErrConnectorUnavailable = errors.New("connector unavailable")
+	ErrUnknownConnectorType = errors.New("unknown connector type")
 )
 
+// Factory는 설정으로부터 커넥터를 생성합니다
+type Factory func(name string, cfg config.ConnectorConfig) (Connector, error)
+
+// factories는 타입 이름별 커넥터 생성 함수입니다
+var factories = map[string]Factory{
+	"aider": func(name string, cfg config.ConnectorConfig) (Connector, error) {
+		if err := checkPromptMode(cfg, PromptModeFlag); err != nil {
+			return nil, err
+		}
+		return NewAiderConnector(name, cfg), nil
+	},
+	"claude": func(name string, cfg config.ConnectorConfig) (Connector, error) {
+		if err := checkPromptMode(cfg, PromptModeFlag, PromptModeStdin); err != nil {
+			return nil, err
+		}
+		return NewClaudeConnector(name, cfg), nil
+	},
+	"codex": func(name string, cfg config.ConnectorConfig) (Connector, error) {
+		if err := checkPromptMode(cfg, PromptModeFlag, PromptModeStdin); err != nil {
+			return nil, err
+		}
+		return NewCodexConnector(name, cfg), nil
+	},
+	"gemini": func(name string, cfg config.ConnectorConfig) (Connector, error) {
+		if err := checkPromptMode(cfg, PromptModeFlag, PromptModeStdin); err != nil {
+			return nil, err
+		}
+		return NewGeminiConnector(name, cfg), nil
+	},
+	"generic": func(name string, cfg config.ConnectorConfig) (Connector, error) {
+		return NewGenericConnector(name, cfg)
+	},
+}
+
+// checkPromptMode는 내장 커넥터가 지원하는 prompt.mode인지 확인합니다
+// 내장 커넥터의 flag 모드는 CLI 고유의 프롬프트 인자를 사용합니다 (prompt.flag 무시)
+func checkPromptMode(cfg config.ConnectorConfig, supported ...string) error {
+	if cfg.Prompt.Mode == "" || slices.Contains(supported, cfg.Prompt.Mode) {
+		return nil
+	}
+	return fmt.Errorf("unsupported prompt.mode %q", cfg.Prompt.Mode)
+}
+
+// New는 설정된 타입에 맞는 커넥터를 생성합니다
+// 타입이 비어있으면 이름과 같은 내장 타입을, 없으면 generic을 사용합니다
+func New(name string, cfg config.ConnectorConfig) (Connector, error) {
+	connectorType := cfg.Type
+	if connectorType == "" {
+		connectorType = "generic"
+		if _, ok := factories[name]; ok {
+			connectorType = name
+		}
+	}
+
+	factory, ok := factories[connectorType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownConnectorType, connectorType)
+	}
+
+	return factory(name, cfg)
+}
+
 // Connector 인터페이스 - runner.Connector와 일치
 type Connector interface {
 	Name() string
-	BuildCommand(prompt string) *exec.Cmd
+	BuildCommand(prompt string, opts runner.Options) *exec.Cmd
 	ParseLine(line string) (*runner.Event, error)
 	IsAvailable() bool
+	// SupportedOptions는 이 커넥터가 플래그로 변환할 수 있는 옵션 이름을 반환합니다
+	SupportedOptions() []string
 }
 
 // Registry는 사용 가능한 커녅터를 관리합니다
 type Registry struct {
 	connectors map[string]Connector
+	configs    map[string]config.ConnectorConfig // 가용성 검사에 사용 (설정으로 등록된 커넥터만)
+	statuses   map[string]*Status                // 마지막 가용성 검사 결과
+	logger     zerolog.Logger
+	stopProbe  chan struct{}
 	mu         sync.RWMutex
 }
 
 // NewRegistry는 새로운 커녅터 레지스트리를 생성합니다
-func NewRegistry() *Registry {
+func NewRegistry(logger zerolog.Logger) *Registry {
 	return &Registry{
 		connectors: make(map[string]Connector),
+		configs:    make(map[string]config.ConnectorConfig),
+		statuses:   make(map[string]*Status),
+		logger:     logger.With().Str("component", "registry").Logger(),
 	}
 }
 
@@ -52,7 +129,7 @@ func (r *Registry) Get(name string) (Connector, error) {
 		return nil, ErrConnectorNotFound
 	}
 
-	if !connector.IsAvailable() {
+	if !r.usable(name, connector) {
 		return nil, ErrConnectorUnavailable
 	}
 
@@ -68,6 +145,7 @@ func (r *Registry) List() []string {
 	for name := range r.connectors {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -78,15 +156,46 @@ func (r *Registry) Available() []string {
 
 	names := make([]string, 0, len(r.connectors))
 	for name, connector := range r.connectors {
-		if connector.IsAvailable() {
+		if r.usable(name, connector) {
 			names = append(names, name)
 		}
 	}
+	sort.Strings(names)
 	return names
 }
 
+// usable은 설정에서 활성화되어 있고 마지막 검사가 성공했는지 확인합니다
+// 아직 검사되지 않은 커넥터는 설정 값만으로 판단합니다 (호출자가 잠금을 보유해야 함)
+func (r *Registry) usable(name string, connector Connector) bool {
+	if !connector.IsAvailable() {
+		return false
+	}
+	if status, ok := r.statuses[name]; ok {
+		return status.Available
+	}
+	return true
+}
+
 // SetupFromConfig는 설정을 기반으로 커녅터를 등록합니다
-func (r *Registry) SetupFromConfig(cfg *config.Config) {
-	// Claude 커녅터 등록
-	r.Register(NewClaudeConnector(cfg.Connectors.Claude))
+func (r *Registry) SetupFromConfig(cfg *config.Config) error {
+	// 등록 순서를 일정하게 유지하기 위해 이름순으로 정렬
+	names := make([]string, 0, len(cfg.Connectors))
+	for name := range cfg.Connectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		connector, err := New(name, cfg.Connectors[name])
+		if err != nil {
+			return fmt.Errorf("connector %q: %w", name, err)
+		}
+		r.Register(connector)
+
+		r.mu.Lock()
+		r.configs[name] = cfg.Connectors[name]
+		r.mu.Unlock()
+	}
+
+	return nil
 }
@@ -0,0 +1,195 @@
+package connector
+
+import (
+	"encoding/json"
+	"os/exec"
+	"strings"
+
+	"cli-runner/config"
+	"cli-runner/runner"
+)
+
+// geminiOptionFlags는 Gemini CLI의 옵션 플래그입니다
+var geminiOptionFlags = map[string]string{
+	runner.OptionModel:        "--model",
+	runner.OptionAllowedTools: "--allowed-tools",
+}
+
+// GeminiConnector는 Gemini CLI를 위한 Connector를 구현합니다
+// Gemini CLI의 stream-json 출력(--output-format stream-json)을 runner 이벤트로 변환합니다
+type GeminiConnector struct {
+	name   string
+	config config.ConnectorConfig
+}
+
+// NewGeminiConnector는 새로운 Gemini 커넥터를 생성합니다
+func NewGeminiConnector(name string, cfg config.ConnectorConfig) *GeminiConnector {
+	return &GeminiConnector{
+		name:   name,
+		config: cfg,
+	}
+}
+
+// Name은 커넥터 이름을 반환합니다
+func (c *GeminiConnector) Name() string {
+	return c.name
+}
+
+// IsAvailable은 커넥터를 사용할 수 있는지 여부를 반환합니다
+func (c *GeminiConnector) IsAvailable() bool {
+	return c.config.Available
+}
+
+// SupportedOptions는 지원하는 옵션 이름을 반환합니다
+func (c *GeminiConnector) SupportedOptions() []string {
+	return optionNames(geminiOptionFlags)
+}
+
+// PromptViaStdin은 prompt.mode가 stdin인 경우 프롬프트를 stdin으로 전달합니다
+func (c *GeminiConnector) PromptViaStdin() bool {
+	return c.config.Prompt.Mode == PromptModeStdin
+}
+
+// BuildCommand는 실행할 명령을 구축합니다
+func (c *GeminiConnector) BuildCommand(prompt string, opts runner.Options) *exec.Cmd {
+	// 구축: gemini [설정의 args] [옵션 플래그] [-p "prompt"]
+	// stdin 모드에서는 -p 없이 실행하면 stdin 전체를 프롬프트로 사용
+	args := append([]string{}, c.config.Args...)
+	args = append(args, optionFlags(opts, geminiOptionFlags)...)
+	if !c.PromptViaStdin() {
+		args = append(args, "-p", prompt)
+	}
+	return exec.Command(c.config.Command, args...)
+}
+
+// ParseLine은 단일 라인을 파싱합니다 (최종 응답 조합에는 NewParser가 사용됨)
+func (c *GeminiConnector) ParseLine(line string) (*runner.Event, error) {
+	return c.NewParser().ParseLine(line)
+}
+
+// NewParser는 실행마다 assistant 응답을 누적하는 파서를 생성합니다
+func (c *GeminiConnector) NewParser() runner.OutputParser {
+	return &geminiParser{}
+}
+
+// geminiEvent는 Gemini stream-json 라인에서 필요한 필드입니다
+type geminiEvent struct {
+	Type       string          `json:"type"`
+	SessionID  string          `json:"session_id"`
+	Model      string          `json:"model"`
+	Role       string          `json:"role"`
+	Content    string          `json:"content"`
+	Delta      bool            `json:"delta"`
+	ToolName   string          `json:"tool_name"`
+	ToolID     string          `json:"tool_id"`
+	Parameters json.RawMessage `json:"parameters"`
+	Status     string          `json:"status"`
+	Output     string          `json:"output"`
+	Severity   string          `json:"severity"`
+	Message    string          `json:"message"`
+	Error      struct {
+		Message string `json:"message"`
+	} `json:"error"`
+	Stats struct {
+		InputTokens  int64 `json:"input_tokens"`
+		OutputTokens int64 `json:"output_tokens"`
+		DurationMs   int64 `json:"duration_ms"`
+	} `json:"stats"`
+}
+
+// geminiParser는 Gemini 출력을 stream/result/error 이벤트로 변환합니다
+type geminiParser struct {
+	response strings.Builder // assistant 메시지 누적
+}
+
+// ParseLine은 Gemini stream-json 라인을 파싱합니다
+func (p *geminiParser) ParseLine(line string) (*runner.Event, error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil, nil
+	}
+
+	var parsed geminiEvent
+	if err := json.Unmarshal([]byte(line), &parsed); err != nil {
+		// 유효한 JSON이 아님, 건너뛰기
+		return nil, nil
+	}
+
+	event := &runner.Event{Type: "stream", Data: json.RawMessage(line)}
+
+	switch parsed.Type {
+	case "init":
+		event.Normalized = []runner.Normalized{{Kind: runner.KindSystem, SessionID: parsed.SessionID, Model: parsed.Model}}
+
+	case "message":
+		if parsed.Role == "assistant" {
+			p.response.WriteString(parsed.Content)
+			event.Normalized = []runner.Normalized{{Kind: runner.KindText, Text: parsed.Content, Delta: parsed.Delta}}
+		}
+
+	case "tool_use":
+		event.Normalized = []runner.Normalized{{
+			Kind: runner.KindToolUse,
+			Tool: &runner.ToolCall{ID: parsed.ToolID, Name: parsed.ToolName, Input: parsed.Parameters},
+		}}
+
+	case "tool_result":
+		output := parsed.Output
+		if output == "" {
+			output = parsed.Error.Message
+		}
+		event.Normalized = []runner.Normalized{{
+			Kind:    runner.KindToolResult,
+			Tool:    &runner.ToolCall{ID: parsed.ToolID, Output: output},
+			IsError: parsed.Status == "error",
+		}}
+
+	case "error":
+		// warning은 일반 스트림으로 전달하고 error만 에러 이벤트로 변환
+		event.Normalized = []runner.Normalized{runner.ErrorMessage(parsed.Message)}
+		if parsed.Severity != "warning" {
+			event.Type = "error"
+		}
+
+	case "result":
+		// Gemini의 result에는 응답 본문이 없으므로 누적한 assistant 메시지를 result 필드로 추가
+		data, err := p.resultData(line)
+		if err != nil {
+			return nil, err
+		}
+		event.Type = "result"
+		event.Data = data
+		event.Normalized = []runner.Normalized{
+			{
+				Kind: runner.KindUsage,
+				Usage: &runner.Usage{
+					InputTokens:  parsed.Stats.InputTokens,
+					OutputTokens: parsed.Stats.OutputTokens,
+					DurationMs:   parsed.Stats.DurationMs,
+				},
+			},
+			{Kind: runner.KindResult, Text: p.response.String(), IsError: parsed.Status == "error"},
+		}
+	}
+
+	return event, nil
+}
+
+// Finish는 남은 이벤트가 없으므로 아무것도 반환하지 않습니다
+func (p *geminiParser) Finish(bool) ([]*runner.Event, error) {
+	return nil, nil
+}
+
+// resultData는 result 라인에 누적된 응답을 result 필드로 추가합니다
+func (p *geminiParser) resultData(line string) (json.RawMessage, error) {
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &result); err != nil {
+		return nil, err
+	}
+
+	if _, exists := result["result"]; !exists {
+		result["result"] = p.response.String()
+	}
+
+	return json.Marshal(result)
+}
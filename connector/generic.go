@@ -0,0 +1,290 @@
+package connector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"cli-runner/config"
+	"cli-runner/runner"
+)
+
+// 프롬프트 전달 방식
+const (
+	PromptModeFlag       = "flag"
+	PromptModePositional = "positional"
+	PromptModeStdin      = "stdin"
+)
+
+// 출력 형식
+const (
+	OutputFormatNDJSON = "ndjson"
+	OutputFormatText   = "text"
+	OutputFormatJSON   = "json"
+)
+
+// promptPlaceholder는 args 안에서 프롬프트로 치환되는 자리표시자입니다
+const promptPlaceholder = "{{prompt}}"
+
+// GenericConnector는 설정만으로 동작하는 범용 Connector를 구현합니다
+// 새로운 CLI는 config.yaml에 command, prompt, output 규칙을 정의하여 추가합니다
+type GenericConnector struct {
+	name        string
+	config      config.ConnectorConfig
+	optionFlags map[string]string
+}
+
+// NewGenericConnector는 설정을 검증하고 새로운 범용 커넥터를 생성합니다
+func NewGenericConnector(name string, cfg config.ConnectorConfig) (*GenericConnector, error) {
+	if cfg.Command == "" {
+		return nil, fmt.Errorf("command is required")
+	}
+
+	// 기본값 적용
+	if cfg.Prompt.Mode == "" {
+		cfg.Prompt.Mode = PromptModePositional
+	}
+	if cfg.Output.Format == "" {
+		cfg.Output.Format = OutputFormatText
+	}
+	if cfg.Output.DefaultType == "" {
+		cfg.Output.DefaultType = "stream"
+		if cfg.Output.Format == OutputFormatJSON {
+			// 단일 JSON 출력은 그 자체가 최종 결과
+			cfg.Output.DefaultType = "result"
+		}
+	}
+
+	switch cfg.Prompt.Mode {
+	case PromptModeFlag:
+		if cfg.Prompt.Flag == "" {
+			return nil, fmt.Errorf("prompt.flag is required when prompt.mode is %q", PromptModeFlag)
+		}
+	case PromptModePositional, PromptModeStdin:
+	default:
+		return nil, fmt.Errorf("unsupported prompt.mode %q", cfg.Prompt.Mode)
+	}
+
+	switch cfg.Output.Format {
+	case OutputFormatNDJSON, OutputFormatText, OutputFormatJSON:
+	default:
+		return nil, fmt.Errorf("unsupported output.format %q", cfg.Output.Format)
+	}
+
+	for i, rule := range cfg.Output.Rules {
+		if rule.Field == "" || rule.Type == "" {
+			return nil, fmt.Errorf("output.rules[%d]: field and type are required", i)
+		}
+	}
+
+	flags := make(map[string]string, len(cfg.Options))
+	for i, option := range cfg.Options {
+		if !slices.Contains(runner.OptionNames, option.Name) {
+			return nil, fmt.Errorf("options[%d]: unknown option %q", i, option.Name)
+		}
+		if option.Flag == "" {
+			return nil, fmt.Errorf("options[%d]: flag is required", i)
+		}
+		flags[option.Name] = option.Flag
+	}
+
+	return &GenericConnector{
+		name:        name,
+		config:      cfg,
+		optionFlags: flags,
+	}, nil
+}
+
+// Name은 커넥터 이름을 반환합니다
+func (c *GenericConnector) Name() string {
+	return c.name
+}
+
+// IsAvailable은 커넥터를 사용할 수 있는지 여부를 반환합니다
+func (c *GenericConnector) IsAvailable() bool {
+	return c.config.Available
+}
+
+// SupportedOptions는 options 설정에 정의된 옵션 이름을 반환합니다
+func (c *GenericConnector) SupportedOptions() []string {
+	return optionNames(c.optionFlags)
+}
+
+// BuildCommand는 prompt 설정에 따라 실행할 명령을 구축합니다
+func (c *GenericConnector) BuildCommand(prompt string, opts runner.Options) *exec.Cmd {
+	args := make([]string, 0, len(c.config.Args)+2)
+
+	// args에 자리표시자가 있으면 해당 위치에 프롬프트를 치환
+	replaced := false
+	for _, arg := range c.config.Args {
+		if c.config.Prompt.Mode != PromptModeStdin && strings.Contains(arg, promptPlaceholder) {
+			arg = strings.ReplaceAll(arg, promptPlaceholder, prompt)
+			replaced = true
+		}
+		args = append(args, arg)
+	}
+
+	// 옵션 플래그는 프롬프트 앞에 추가
+	args = append(args, optionFlags(opts, c.optionFlags)...)
+
+	if !replaced {
+		switch c.config.Prompt.Mode {
+		case PromptModeFlag:
+			args = append(args, c.config.Prompt.Flag, prompt)
+		case PromptModePositional:
+			// -로 시작하는 프롬프트가 플래그로 해석되지 않도록 -- 뒤에 전달
+			args = append(args, "--", prompt)
+		}
+	}
+
+	return exec.Command(c.config.Command, args...)
+}
+
+// PromptViaStdin은 prompt.mode가 stdin인 경우 러너가 프롬프트를 stdin으로 전달하도록 합니다
+func (c *GenericConnector) PromptViaStdin() bool {
+	return c.config.Prompt.Mode == PromptModeStdin
+}
+
+// ParseLine은 단일 라인을 파싱합니다 (json 형식은 전체 출력이 필요하므로 NewParser를 사용)
+func (c *GenericConnector) ParseLine(line string) (*runner.Event, error) {
+	return c.NewParser().ParseLine(line)
+}
+
+// NewParser는 실행마다 독립된 출력 파서를 생성합니다
+func (c *GenericConnector) NewParser() runner.OutputParser {
+	return &genericParser{config: c.config.Output}
+}
+
+// genericParser는 output 설정에 따라 CLI 출력을 이벤트로 변환합니다
+type genericParser struct {
+	config config.OutputConfig
+	buffer strings.Builder // json 형식에서 전체 출력을 모음
+}
+
+// ParseLine은 출력 형식에 따라 한 라인을 이벤트로 변환합니다
+func (p *genericParser) ParseLine(line string) (*runner.Event, error) {
+	switch p.config.Format {
+	case OutputFormatJSON:
+		// 출력이 끝날 때까지 모음
+		p.buffer.WriteString(line)
+		p.buffer.WriteByte('\n')
+		return nil, nil
+
+	case OutputFormatText:
+		if strings.TrimSpace(line) == "" {
+			return nil, nil
+		}
+		data, err := json.Marshal(map[string]string{"text": line})
+		if err != nil {
+			return nil, err
+		}
+		return &runner.Event{
+			Type:       p.config.DefaultType,
+			Data:       data,
+			Normalized: []runner.Normalized{runner.TextDelta(line + "\n")},
+		}, nil
+
+	default:
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return nil, nil
+		}
+		return p.parseJSON(line), nil
+	}
+}
+
+// Finish는 json 형식에서 모아둔 전체 출력을 하나의 이벤트로 변환합니다
+// 실패한 실행의 출력도 에러 정보를 담고 있을 수 있으므로 종료 상태와 관계없이 변환합니다
+func (p *genericParser) Finish(bool) ([]*runner.Event, error) {
+	if p.config.Format != OutputFormatJSON {
+		return nil, nil
+	}
+
+	output := strings.TrimSpace(p.buffer.String())
+	p.buffer.Reset()
+	if output == "" {
+		return nil, nil
+	}
+
+	// 줄바꿈이 포함된 JSON을 한 줄로 압축
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(output)); err != nil {
+		return nil, fmt.Errorf("invalid JSON output: %w", err)
+	}
+
+	event := p.parseJSON(compact.String())
+	if event == nil {
+		return nil, nil
+	}
+	return []*runner.Event{event}, nil
+}
+
+// parseJSON은 JSON 문자열에 타입 매핑 규칙을 적용합니다
+// 유효한 JSON 객체가 아니면 nil을 반환합니다 (라인 무시)
+func (p *genericParser) parseJSON(raw string) *runner.Event {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &obj); err != nil {
+		return nil
+	}
+
+	eventType := p.matchType(obj)
+	return &runner.Event{
+		Type:       eventType,
+		Data:       json.RawMessage(raw),
+		Normalized: normalizeGeneric(eventType, obj),
+	}
+}
+
+// normalizeGeneric은 result/error 타입으로 매핑된 이벤트만 정규화합니다
+// 그 외 타입은 스키마를 알 수 없으므로 원본 Data만 전달됩니다
+func normalizeGeneric(eventType string, obj map[string]interface{}) []runner.Normalized {
+	switch eventType {
+	case "result":
+		text, _ := lookupField(obj, "result")
+		return []runner.Normalized{{Kind: runner.KindResult, Text: text}}
+	case "error":
+		message, ok := lookupField(obj, "message")
+		if !ok {
+			message, _ = lookupField(obj, "error")
+		}
+		return []runner.Normalized{runner.ErrorMessage(message)}
+	}
+	return nil
+}
+
+// matchType은 첫 번째로 일치하는 규칙의 이벤트 타입을 반환합니다
+func (p *genericParser) matchType(obj map[string]interface{}) string {
+	for _, rule := range p.config.Rules {
+		value, ok := lookupField(obj, rule.Field)
+		if !ok {
+			continue
+		}
+		if rule.Equals == "" || value == rule.Equals {
+			return rule.Type
+		}
+	}
+	return p.config.DefaultType
+}
+
+// lookupField는 점으로 구분된 경로의 값을 문자열로 반환합니다
+func lookupField(obj map[string]interface{}, path string) (string, bool) {
+	var current interface{} = obj
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		current, ok = m[key]
+		if !ok || current == nil {
+			return "", false
+		}
+	}
+
+	if s, ok := current.(string); ok {
+		return s, true
+	}
+	return fmt.Sprint(current), true
+}
@@ -0,0 +1,61 @@
+package connector
+
+import (
+	"slices"
+	"testing"
+
+	"cli-runner/config"
+	"cli-runner/runner"
+)
+
+func TestGenericBuildCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		prompt config.PromptConfig
+		want   []string // 명령 이름을 제외한 인자
+	}{
+		{
+			name:   "positional prompt after --",
+			args:   []string{"--json"},
+			prompt: config.PromptConfig{Mode: PromptModePositional},
+			want:   []string{"--json", "--model", "m1", "--", "-rm everything"},
+		},
+		{
+			name:   "placeholder",
+			args:   []string{"run", "{{prompt}}", "--json"},
+			prompt: config.PromptConfig{Mode: PromptModePositional},
+			want:   []string{"run", "-rm everything", "--json", "--model", "m1"},
+		},
+		{
+			name:   "flag",
+			prompt: config.PromptConfig{Mode: PromptModeFlag, Flag: "--prompt"},
+			want:   []string{"--model", "m1", "--prompt", "-rm everything"},
+		},
+		{
+			name:   "stdin leaves the placeholder untouched",
+			args:   []string{"{{prompt}}"},
+			prompt: config.PromptConfig{Mode: PromptModeStdin},
+			want:   []string{"{{prompt}}", "--model", "m1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector, err := NewGenericConnector("cli", config.ConnectorConfig{
+				Command: "cli",
+				Args:    tt.args,
+				Prompt:  tt.prompt,
+				Options: []config.OptionConfig{{Name: runner.OptionModel, Flag: "--model"}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cmd := connector.BuildCommand("-rm everything", runner.Options{Model: "m1"})
+			if got := cmd.Args[1:]; !slices.Equal(got, tt.want) {
+				t.Errorf("args = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
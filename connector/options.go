@@ -0,0 +1,70 @@
+package connector
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cli-runner/runner"
+)
+
+var ErrUnsupportedOption = errors.New("unsupported option")
+
+// ValidateOptions는 커넥터가 지원하지 않는 옵션이나 잘못된 값이 지정되었는지 확인합니다
+func ValidateOptions(c Connector, opts runner.Options) error {
+	if opts.MaxTurns < 0 {
+		return fmt.Errorf("%s must be positive", runner.OptionMaxTurns)
+	}
+
+	supported := make(map[string]bool)
+	for _, name := range c.SupportedOptions() {
+		supported[name] = true
+	}
+
+	var unsupported []string
+	for _, name := range opts.Names() {
+		if !supported[name] {
+			unsupported = append(unsupported, name)
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%w for connector %q: %s", ErrUnsupportedOption, c.Name(), strings.Join(unsupported, ", "))
+	}
+
+	return nil
+}
+
+// optionFlags는 옵션 이름별 CLI 플래그를 사용하여 인자를 구축합니다
+// 매핑에 없는 옵션은 무시됩니다 (ValidateOptions에서 미리 거부됨)
+func optionFlags(opts runner.Options, flags map[string]string) []string {
+	var args []string
+
+	add := func(name, value string) {
+		if flag, ok := flags[name]; ok && value != "" {
+			args = append(args, flag, value)
+		}
+	}
+
+	add(runner.OptionModel, opts.Model)
+	add(runner.OptionSystemPrompt, opts.SystemPrompt)
+	add(runner.OptionAllowedTools, strings.Join(opts.AllowedTools, ","))
+	if opts.MaxTurns > 0 {
+		add(runner.OptionMaxTurns, strconv.Itoa(opts.MaxTurns))
+	}
+	add(runner.OptionResume, opts.Resume)
+
+	return args
+}
+
+// optionNames는 플래그 매핑에 정의된 옵션 이름을 반환합니다
+func optionNames(flags map[string]string) []string {
+	names := make([]string, 0, len(flags))
+	for _, name := range runner.OptionNames {
+		if _, ok := flags[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
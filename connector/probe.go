@@ -0,0 +1,182 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cli-runner/config"
+)
+
+// Status는 커넥터의 가용성 검사 결과를 나타냅니다
+type Status struct {
+	Name        string     `json:"name" example:"claude"`
+	Available   bool       `json:"available" example:"true"`
+	Version     string     `json:"version,omitempty" example:"2.0.14 (Claude Code)"`
+	Path        string     `json:"path,omitempty" example:"/usr/local/bin/claude"`
+	LastChecked *time.Time `json:"lastChecked,omitempty" example:"2024-01-01T12:00:00Z"`
+	Error       string     `json:"error,omitempty" example:"executable file not found in $PATH"`
+}
+
+// Statuses는 모든 커넥터의 마지막 검사 결과를 이름순으로 반환합니다
+func (r *Registry) Statuses() []Status {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	statuses := make([]Status, 0, len(r.connectors))
+	for name, connector := range r.connectors {
+		status := Status{Name: name, Available: r.usable(name, connector)}
+		if probed, ok := r.statuses[name]; ok {
+			status = *probed
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// ProbeAll은 설정으로 등록된 모든 커넥터를 동시에 검사합니다
+func (r *Registry) ProbeAll(timeout time.Duration) {
+	r.mu.RLock()
+	targets := make(map[string]config.ConnectorConfig, len(r.configs))
+	for name, cfg := range r.configs {
+		targets[name] = cfg
+	}
+	r.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for name, cfg := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status := probe(name, cfg, timeout)
+			r.setStatus(status)
+		}()
+	}
+	wg.Wait()
+}
+
+// StartProbing은 시작 시 한 번 검사한 뒤 주기적으로 커넥터를 다시 검사합니다
+func (r *Registry) StartProbing(interval, timeout time.Duration) {
+	r.ProbeAll(timeout)
+
+	if interval <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	r.stopProbe = make(chan struct{})
+	stop := r.stopProbe
+	r.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		r.logger.Info().
+			Dur("interval", interval).
+			Msg("Connector probing started")
+
+		for {
+			select {
+			case <-ticker.C:
+				r.ProbeAll(timeout)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopProbing은 주기적 검사를 중지합니다
+func (r *Registry) StopProbing() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopProbe != nil {
+		close(r.stopProbe)
+		r.stopProbe = nil
+	}
+}
+
+// setStatus는 검사 결과를 저장하고 가용성이 바뀐 경우 로깅합니다
+func (r *Registry) setStatus(status Status) {
+	r.mu.Lock()
+	previous, existed := r.statuses[status.Name]
+	r.statuses[status.Name] = &status
+	r.mu.Unlock()
+
+	if existed && previous.Available == status.Available {
+		return
+	}
+
+	if status.Available {
+		r.logger.Info().
+			Str("connector", status.Name).
+			Str("version", status.Version).
+			Str("path", status.Path).
+			Msg("Connector available")
+	} else {
+		r.logger.Warn().
+			Str("connector", status.Name).
+			Str("reason", status.Error).
+			Msg("Connector unavailable")
+	}
+}
+
+// probe는 실행 파일을 PATH에서 찾고 버전 명령이 제한 시간 안에 성공하는지 확인합니다
+func probe(name string, cfg config.ConnectorConfig, timeout time.Duration) Status {
+	now := time.Now()
+	status := Status{Name: name, LastChecked: &now}
+
+	if !cfg.Available {
+		status.Error = "disabled in configuration"
+		return status
+	}
+
+	path, err := exec.LookPath(cfg.Command)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Path = path
+
+	versionArgs := cfg.VersionArgs
+	if len(versionArgs) == 0 {
+		versionArgs = []string{"--version"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, path, versionArgs...).CombinedOutput()
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			status.Error = "version check timed out after " + timeout.String()
+		} else {
+			status.Error = "version check failed: " + err.Error()
+		}
+		return status
+	}
+
+	status.Version = firstLine(string(output))
+	status.Available = true
+	return status
+}
+
+// firstLine은 비어있지 않은 첫 번째 라인을 반환합니다
+func firstLine(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
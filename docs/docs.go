@@ -25,7 +25,7 @@ const docTemplate = `{
     "paths": {
         "/connectors": {
             "get": {
-                "description": "사용 가능한 AI CLI 커넥터 목록을 조회합니다",
+                "description": "사용 가능한 AI CLI 커넥터 목록과 커넥터별 가용성 검사 결과(버전, 경로, 실패 사유)를 조회합니다",
                 "produces": [
                     "application/json"
                 ],
@@ -39,8 +39,112 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ConnectorListResponse"
                         }
+                    },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한 초과 (rateLimit.routes)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/history": {
+            "get": {
+                "description": "저장소에 보관된 실행 기록을 최근 시작 순으로 조회합니다 (메모리에서 정리되었거나 서버 재시작 이전의 프로세스 포함)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process"
+                ],
+                "summary": "실행 기록 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "커넥터 이름",
+                        "name": "connector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "쉼표로 구분된 상태 (예: failed,interrupted)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "최대 개수 (기본값 50, 최대 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "건너뛸 개수",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "실행 기록",
+                        "schema": {
+                            "$ref": "#/definitions/api.HistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 조회 조건",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한 초과 (rateLimit.routes)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "저장소 조회 실패",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/process/{id}": {
@@ -69,13 +173,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ProcessStatus"
                         }
                     },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "프로세스를 찾을 수 없음",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한 초과 (rateLimit.routes)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             },
             "delete": {
                 "description": "실행 중인 프로세스를 종료하고 삭제합니다",
@@ -108,18 +235,135 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없거나 다른 키가 실행한 프로세스",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "프로세스를 찾을 수 없음",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한 초과 (rateLimit.routes)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/process/{id}/continue": {
+            "post": {
+                "description": "이전 프로세스의 CLI 세션을 resume하여 새로운 프로세스를 실행합니다",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process"
+                ],
+                "summary": "이전 대화 이어가기",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "이전 프로세스 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "이어가기 요청",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ContinueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "프로세스가 생성됨",
+                        "schema": {
+                            "$ref": "#/definitions/api.ContinueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 resume을 지원하지 않는 커넥터",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없거나 다른 키가 실행한 프로세스",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "프로세스를 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이전 프로세스가 실행 중이거나 세션 ID가 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한, 최대 동시 실행 수 초과, 대기열 가득 참 또는 테넌트 일일 사용량 초과",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 오류",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "서버 종료 중",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/processes": {
             "get": {
-                "description": "모든 프로세스의 목록을 조회합니다",
+                "description": "요청한 키와 같은 테넌트의 프로세스 목록을 조회합니다 (admin은 전체, tenant로 특정 테넌트만 조회)",
                 "produces": [
                     "application/json"
                 ],
@@ -127,14 +371,82 @@ const docTemplate = `{
                     "process"
                 ],
                 "summary": "프로세스 목록 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "테넌트 이름 (admin이 아니면 자신의 테넌트만 가능)",
+                        "name": "tenant",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "프로세스 목록",
                         "schema": {
                             "$ref": "#/definitions/api.ProcessListResponse"
                         }
+                    },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한 초과 (rateLimit.routes)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/rate-limits": {
+            "get": {
+                "description": "라우트별 속도 제한 설정과 서버 시작 이후 거부한 요청 수를 클라이언트별로 조회합니다 (admin 전용)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "속도 제한 현황",
+                "responses": {
+                    "200": {
+                        "description": "라우트별 속도 제한 현황",
+                        "schema": {
+                            "$ref": "#/definitions/api.RateLimitsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/result-data/{id}": {
@@ -164,13 +476,36 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "프로세스를 찾을 수 없거나 데이터가 만료됨",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한 초과 (rateLimit.routes)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/result/{id}": {
@@ -208,13 +543,36 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "프로세스를 찾을 수 없음",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한 초과 (rateLimit.routes)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/run": {
@@ -249,50 +607,275 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "잘못된 요청",
+                        "description": "잘못된 요청 (options.resume 포함)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한, 최대 동시 실행 수 초과, 대기열 가득 참 또는 테넌트 일일 사용량 초과",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 오류",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "서버 종료 중",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stream/{id}": {
+            "get": {
+                "description": "프로세스의 이벤트를 offset부터 재생한 뒤 실시간 이벤트를 SSE로 스트리밍합니다\n이벤트 로그가 설정되어 있으면 실행 길이와 무관하게 처음부터 재생할 수 있습니다\n이벤트가 없어도 stream.heartbeatInterval마다 \": ping\" 주석을 보내 프록시가 유휴 연결을 끊지 않도록 합니다",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "SSE 스트림 구독",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "프로세스 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "raw",
+                            "envelope"
+                        ],
+                        "type": "string",
+                        "default": "raw",
+                        "description": "data 형식 (raw: CLI 원본 JSON, envelope: 원본과 정규화된 이벤트를 포함한 전체 Event)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "재생을 시작할 이벤트 위치 (0부터 시작)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "마지막으로 받은 이벤트 id, 이후 이벤트부터 전송 (offset보다 우선)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "재연결 시 브라우저가 보내는 마지막 이벤트 id (since보다 우선)",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "block",
+                            "drop",
+                            "disconnect"
+                        ],
+                        "type": "string",
+                        "description": "클라이언트가 느려 구독 버퍼가 가득 찼을 때의 처리 (기본값: stream.backpressure)",
+                        "name": "backpressure",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SSE 이벤트 스트림",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 format, offset, since 또는 backpressure",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "프로세스를 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한 초과 (rateLimit.routes)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/tenants/{id}/usage": {
+            "get": {
+                "description": "테넌트의 동시 실행 수, 오늘의 실행 수와 실행 시간, 적용 중인 제한을 조회합니다 (admin이 아니면 자신의 테넌트만)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tenant"
+                ],
+                "summary": "테넌트 사용량 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "테넌트 이름 (대소문자 구분 없음)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "테넌트 사용량",
+                        "schema": {
+                            "$ref": "#/definitions/api.TenantUsageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "429": {
-                        "description": "최대 동시 실행 수 초과",
+                    "404": {
+                        "description": "API 키, 사용량 제한 설정, 사용 기록이 모두 없는 테넌트이거나 다른 테넌트",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "서버 오류",
+                    "429": {
+                        "description": "요청 속도 제한 초과 (rateLimit.routes)",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/stream/{id}": {
+        "/ws/{id}": {
             "get": {
-                "description": "프로세스의 실시간 이벤트를 SSE로 스트리밍합니다",
-                "produces": [
-                    "text/event-stream"
-                ],
+                "description": "프로세스 이벤트(runner.Event)를 WebSocket으로 스트리밍하고 제어 메시지(stop, input, subscribe, unsubscribe, ping)를 받습니다\n하나의 연결로 여러 프로세스를 구독할 수 있으며, 서버 메시지는 processId로 구분합니다",
                 "tags": [
                     "stream"
                 ],
-                "summary": "SSE 스트림 구독",
+                "summary": "WebSocket 세션",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "프로세스 ID",
+                        "description": "처음 구독할 프로세스 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "재생을 시작할 이벤트 위치 (0부터 시작)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "마지막으로 받은 이벤트 id, 이후 이벤트부터 전송 (offset보다 우선)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "block",
+                            "drop",
+                            "disconnect"
+                        ],
+                        "type": "string",
+                        "description": "클라이언트가 느려 구독 버퍼가 가득 찼을 때의 처리 (기본값: stream.backpressure)",
+                        "name": "backpressure",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "SSE 이벤트 스트림",
+                    "101": {
+                        "description": "프로토콜 전환",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.WSServerMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 offset, since 또는 backpressure",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API 키가 없거나 올바르지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "API 키에 필요한 권한(scope)이 없음",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "404": {
@@ -300,8 +883,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "429": {
+                        "description": "요청 속도 제한 초과 (rateLimit.routes)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         }
     },
@@ -322,6 +916,79 @@ const docTemplate = `{
                 "count": {
                     "type": "integer",
                     "example": 2
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/connector.Status"
+                    }
+                }
+            }
+        },
+        "api.ContinueRequest": {
+            "type": "object",
+            "required": [
+                "prompt"
+            ],
+            "properties": {
+                "idleTimeoutSeconds": {
+                    "description": "이벤트 없이 대기할 최대 시간",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 120
+                },
+                "options": {
+                    "$ref": "#/definitions/runner.Options"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "interactive",
+                        "batch"
+                    ],
+                    "example": "interactive"
+                },
+                "prompt": {
+                    "type": "string",
+                    "example": "Now add tests for it"
+                },
+                "timeoutSeconds": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 600
+                },
+                "workDir": {
+                    "type": "string",
+                    "example": "/path/to/project"
+                }
+            }
+        },
+        "api.ContinueResponse": {
+            "type": "object",
+            "properties": {
+                "parentId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "processId": {
+                    "type": "string",
+                    "example": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+                },
+                "queuePosition": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "sessionId": {
+                    "type": "string",
+                    "example": "7f3c9a2e-1b4d-4c8a-9e6f-2d5b8a1c3e7f"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "queued"
+                },
+                "waitReason": {
+                    "type": "string",
+                    "example": "global_limit"
                 }
             }
         },
@@ -338,6 +1005,29 @@ const docTemplate = `{
                 }
             }
         },
+        "api.HistoryResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "processes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ProcessStatus"
+                    }
+                }
+            }
+        },
         "api.MessageResponse": {
             "type": "object",
             "properties": {
@@ -374,6 +1064,11 @@ const docTemplate = `{
                 },
                 "output": {
                     "type": "string"
+                },
+                "reason": {
+                    "description": "강제 종료 사유: timeout, idle_timeout, user_stop",
+                    "type": "string",
+                    "example": "idle_timeout"
                 }
             }
         },
@@ -392,24 +1087,112 @@ const docTemplate = `{
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
+                "idleTimeoutSeconds": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "options": {
+                    "$ref": "#/definitions/runner.Options"
+                },
+                "owner": {
+                    "description": "실행한 API 키 이름 (인증 사용 시)",
+                    "type": "string",
+                    "example": "ide-plugin"
+                },
+                "parentId": {
+                    "type": "string",
+                    "example": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+                },
+                "priority": {
+                    "type": "string",
+                    "example": "interactive"
+                },
                 "prompt": {
                     "type": "string",
                     "example": "Hello"
                 },
+                "queuePosition": {
+                    "description": "queued 상태일 때 같은 우선순위 안에서 1부터 시작하는 대기 순번",
+                    "type": "integer",
+                    "example": 2
+                },
+                "sessionId": {
+                    "type": "string",
+                    "example": "7f3c9a2e-1b4d-4c8a-9e6f-2d5b8a1c3e7f"
+                },
                 "startedAt": {
                     "type": "string",
                     "example": "2024-01-01T12:00:00Z"
                 },
                 "status": {
+                    "description": "queued, pending, running, completed, failed, stopped, interrupted",
                     "type": "string",
                     "example": "running"
                 },
+                "tenant": {
+                    "description": "실행한 키의 테넌트 (인증 사용 시)",
+                    "type": "string",
+                    "example": "platform"
+                },
+                "timeoutSeconds": {
+                    "type": "integer",
+                    "example": 1800
+                },
+                "waitReason": {
+                    "description": "queued 상태일 때 대기 사유: global_limit, connector_limit, tenant_limit, priority",
+                    "type": "string",
+                    "example": "connector_limit"
+                },
                 "workDir": {
                     "type": "string",
                     "example": "/path/to/project"
                 }
             }
         },
+        "api.RateLimitStats": {
+            "type": "object",
+            "properties": {
+                "burst": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "clients": {
+                    "description": "클라이언트(key:\u003c이름\u003e 또는 ip:\u003c주소\u003e)별 거부 수",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "perSeconds": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "requests": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "route": {
+                    "type": "string",
+                    "example": "run"
+                }
+            }
+        },
+        "api.RateLimitsResponse": {
+            "type": "object",
+            "properties": {
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.RateLimitStats"
+                    }
+                }
+            }
+        },
         "api.RunRequest": {
             "type": "object",
             "required": [
@@ -421,10 +1204,33 @@ const docTemplate = `{
                     "type": "string",
                     "example": "claude"
                 },
+                "idleTimeoutSeconds": {
+                    "description": "이벤트 없이 대기할 최대 시간",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 120
+                },
+                "options": {
+                    "$ref": "#/definitions/runner.Options"
+                },
+                "priority": {
+                    "description": "대기열 우선순위 (기본값: interactive)",
+                    "type": "string",
+                    "enum": [
+                        "interactive",
+                        "batch"
+                    ],
+                    "example": "interactive"
+                },
                 "prompt": {
                     "type": "string",
                     "example": "Hello, how are you?"
                 },
+                "timeoutSeconds": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 600
+                },
                 "workDir": {
                     "type": "string",
                     "example": "/path/to/project"
@@ -437,9 +1243,174 @@ const docTemplate = `{
                 "processId": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "queuePosition": {
+                    "description": "같은 우선순위 안에서 1부터 시작하는 대기 순번",
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "description": "대기열에 추가된 경우에만 포함",
+                    "type": "string",
+                    "example": "queued"
+                },
+                "waitReason": {
+                    "type": "string",
+                    "example": "global_limit"
+                }
+            }
+        },
+        "api.TenantLimits": {
+            "type": "object",
+            "properties": {
+                "maxConcurrent": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "maxDailyRuns": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "maxDailyRuntimeSeconds": {
+                    "type": "integer",
+                    "example": 36000
+                }
+            }
+        },
+        "api.TenantUsageResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "실행 슬롯을 차지한 (pending, running) 프로세스 수",
+                    "type": "integer",
+                    "example": 2
+                },
+                "day": {
+                    "description": "집계 날짜 (서버 로컬 시간, 자정에 초기화)",
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "limits": {
+                    "$ref": "#/definitions/api.TenantLimits"
+                },
+                "queued": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "runs": {
+                    "description": "오늘 생성된 프로세스 수",
+                    "type": "integer",
+                    "example": 37
+                },
+                "runtimeSeconds": {
+                    "description": "오늘의 실행 시간 합계 (실행 중인 프로세스 포함)",
+                    "type": "integer",
+                    "example": 5230
+                },
+                "tenant": {
+                    "type": "string",
+                    "example": "platform"
+                }
+            }
+        },
+        "api.WSServerMessage": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "description": "?format=envelope의 Event와 같음",
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "req-1"
+                },
+                "processId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "result": {
+                    "description": "ack의 처리 결과 (input이면 새 processId)",
+                    "type": "object"
+                },
+                "type": {
+                    "description": "event, ack, error, pong",
+                    "type": "string",
+                    "example": "event"
+                }
+            }
+        },
+        "connector.Status": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "error": {
+                    "type": "string",
+                    "example": "executable file not found in $PATH"
+                },
+                "lastChecked": {
+                    "type": "string",
+                    "example": "2024-01-01T12:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "claude"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/usr/local/bin/claude"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "2.0.14 (Claude Code)"
+                }
+            }
+        },
+        "runner.Options": {
+            "type": "object",
+            "properties": {
+                "allowedTools": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Read",
+                        "Edit"
+                    ]
+                },
+                "maxTurns": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "model": {
+                    "type": "string",
+                    "example": "sonnet"
+                },
+                "resume": {
+                    "description": "이어서 진행할 CLI 세션 ID",
+                    "type": "string",
+                    "example": "7f3c9a2e-1b4d-4c8a-9e6f-2d5b8a1c3e7f"
+                },
+                "systemPrompt": {
+                    "type": "string",
+                    "example": "Answer in Korean."
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "\"Bearer \u003cAPI 키\u003e\" 형식 (auth.keys가 설정된 경우에만 필요)",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -450,7 +1421,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api/v1",
 	Schemes:          []string{"http", "https"},
 	Title:            "CLI Runner API",
-	Description:      "AI CLI (Claude, Gemini) 실행 및 SSE 스트리밍 서비스",
+	Description:      "AI CLI (Claude, Gemini, Codex, Aider) 실행 및 SSE 스트리밍 서비스",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
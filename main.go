@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"cli-runner/api"
 	"cli-runner/config"
@@ -15,7 +17,7 @@ import (
 
 // @title CLI Runner API
 // @version 1.0
-// @description AI CLI (Claude, Gemini) 실행 및 SSE 스트리밍 서비스
+// @description AI CLI (Claude, Gemini, Codex, Aider) 실행 및 SSE 스트리밍 서비스
 // @termsOfService http://swagger.io/terms/
 
 // @contact.name API Support
@@ -28,6 +30,11 @@ import (
 // @BasePath /api/v1
 
 // @schemes http https
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description "Bearer <API 키>" 형식 (auth.keys가 설정된 경우에만 필요)
 func main() {
 	// 설정 로드
 	cfg, err := config.Load()
@@ -46,7 +53,11 @@ func main() {
 		Msg("Server configuration loaded")
 
 	// 서버 생성
-	server := api.NewServer(cfg, log)
+	server, err := api.NewServer(cfg, log)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create server")
+		os.Exit(1)
+	}
 	server.SetupRoutes()
 
 	// 리스너로부터 오는 에러를 수신하는 채널
@@ -68,7 +79,26 @@ func main() {
 		os.Exit(1)
 
 	case sig := <-shutdown:
-		log.Info().Str("signal", sig.String()).Msg("Shutdown signal received")
+		log.Info().
+			Str("signal", sig.String()).
+			Str("mode", cfg.Server.Shutdown.Mode).
+			Msg("Shutdown signal received")
+
+		// 프로세스 대기(drainTimeout)와 중지(stopGracePeriod) 후 스트림이 닫힐 시간까지 허용
+		timeout := cfg.Server.Shutdown.DrainTimeout + cfg.Process.StopGracePeriod + 10*time.Second
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		// 종료 중 시그널을 한 번 더 받으면 기다리지 않고 종료
+		go func() {
+			<-shutdown
+			log.Warn().Msg("Second shutdown signal received, exiting immediately")
+			os.Exit(1)
+		}()
+
+		if err := server.Shutdown(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to shut down server gracefully")
+		}
 		log.Info().Msg("Server stopped")
 	}
 }
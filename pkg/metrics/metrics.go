@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace는 모든 메트릭 이름의 접두사입니다
+const namespace = "cli_runner"
+
+// Registry는 서버의 모든 메트릭을 보관합니다 (Go 런타임과 프로세스 메트릭 포함)
+// 기본 레지스트리 대신 사용하므로 의존 라이브러리가 등록한 메트릭은 노출되지 않습니다
+var Registry = prometheus.NewRegistry()
+
+var (
+	// RunDuration은 CLI 프로세스의 실행 시간입니다 (실행 슬롯을 얻은 시점부터 종료까지)
+	RunDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "run_duration_seconds",
+		Help:      "Duration of CLI process runs from start to exit.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+	}, []string{"connector", "status"})
+
+	// QueueWait는 프로세스가 생성된 뒤 실행을 시작하기까지 기다린 시간입니다
+	QueueWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "queue_wait_seconds",
+		Help:      "Time a process waited between creation and start.",
+		Buckets:   []float64{0.01, 0.1, 0.5, 1, 5, 15, 30, 60, 300, 900},
+	}, []string{"connector", "priority"})
+
+	// Events는 커넥터와 이벤트 타입별로 프로세스가 만든 이벤트 수입니다
+	Events = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_total",
+		Help:      "Events emitted by processes.",
+	}, []string{"connector", "type"})
+
+	// DroppedEvents는 느린 구독자에게 전달하지 못한 이벤트 수입니다
+	// policy가 disconnect이면 구독이 끊긴 횟수입니다
+	DroppedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dropped_events_total",
+		Help:      "Events not delivered to slow subscribers, by backpressure policy.",
+	}, []string{"connector", "policy"})
+
+	// Subscribers는 현재 이벤트를 받고 있는 스트림 구독자 수입니다
+	Subscribers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "stream_subscribers",
+		Help:      "Active event stream subscribers.",
+	}, []string{"transport"})
+
+	// CleanupRemoved는 클린업이 메모리에서 제거한 종료된 프로세스 수입니다
+	CleanupRemoved = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cleanup_removed_total",
+		Help:      "Finished processes removed from memory by cleanup.",
+	})
+
+	// StoreWriteErrors는 재시도 후에도 저장소에 반영하지 못하고 버린 쓰기 수입니다
+	StoreWriteErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "store_write_errors_total",
+		Help:      "Process store writes dropped after retries failed.",
+	})
+
+	// HTTPDuration은 라우트별 HTTP 요청 처리 시간입니다
+	// 스트림과 WebSocket은 연결이 끊길 때까지의 시간입니다
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// RateLimited는 속도 제한으로 거부한 요청 수입니다
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Requests rejected by the rate limiter.",
+	}, []string{"route"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		RunDuration,
+		QueueWait,
+		Events,
+		DroppedEvents,
+		Subscribers,
+		CleanupRemoved,
+		StoreWriteErrors,
+		HTTPDuration,
+		RateLimited,
+	)
+}
+
+// Handler는 Registry의 메트릭을 Prometheus 텍스트 형식으로 제공하는 핸들러를 반환합니다
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
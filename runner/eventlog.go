@@ -0,0 +1,24 @@
+package runner
+
+import "time"
+
+// EventLog는 프로세스의 모든 이벤트를 순서대로 보관하는 추가 전용 로그입니다
+// 링 버퍼는 최근 이벤트의 캐시로만 사용하며, 버퍼보다 오래된 이벤트는 로그에서 다시 읽습니다
+// 이벤트의 offset은 프로세스별로 0부터 1씩 증가합니다
+type EventLog interface {
+	// Append는 이벤트를 로그 끝에 추가합니다
+	Append(processID string, event Event) error
+	// Read는 offset부터 최대 limit개(0이면 끝까지)의 이벤트를 순서대로 반환합니다
+	Read(processID string, offset, limit int) ([]Event, error)
+	// Len은 로그에 기록된 이벤트 수를 반환합니다 (로그가 없으면 0)
+	Len(processID string) (int, error)
+	// Finish는 더 이상 이벤트가 추가되지 않는 프로세스의 파일을 닫습니다
+	Finish(processID string) error
+	// Delete는 프로세스의 로그를 삭제합니다
+	Delete(processID string) error
+	// Prune은 before 이전에 마지막으로 기록된 로그를 삭제한 뒤,
+	// 전체 크기가 maxBytes(0이면 제한 없음)를 넘으면 오래된 로그부터 삭제합니다
+	// keep이 true를 반환하는 프로세스의 로그는 삭제하지 않습니다
+	Prune(before time.Time, maxBytes int64, keep func(processID string) bool) (int, error)
+	Close() error
+}
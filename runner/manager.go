@@ -1,11 +1,14 @@
 package runner
 
 import (
+	"context"
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
 	"cli-runner/config"
+	"cli-runner/pkg/metrics"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
@@ -14,75 +17,410 @@ import (
 var (
 	ErrProcessNotFound = errors.New("process not found")
 	ErrMaxConcurrent   = errors.New("max concurrent processes reached")
+	ErrQueueFull       = errors.New("process queue is full")
+	ErrShuttingDown    = errors.New("server is shutting down")
+	ErrTenantQuota     = errors.New("tenant quota exceeded")
 )
 
 // Manager는 모든 실행 중인 프로세스를 관리합니다
+// 전역 또는 커넥터별 동시 실행 수가 가득 차면 새 프로세스는 대기열에서 차례를 기다립니다
 type Manager struct {
 	processes map[string]*Process
+	queue     []*Process              // 실행 대기 중인 프로세스 (먼저 들어온 순서)
+	credits   map[string]int          // 우선순위 클래스별 가중 라운드 로빈 점수
+	usage     map[string]*tenantUsage // 테넌트별 오늘의 사용량
+	dispatch  func(*Process)          // 실행 슬롯을 얻은 프로세스를 시작 (Runner가 등록)
+	store     ProcessStore            // nil이면 메모리에만 보관
+	eventLog  EventLog                // nil이면 링 버퍼에 남은 이벤트만 재생 가능
+	closing   bool                    // Shutdown 이후 새 프로세스를 받지 않음
+	stopClean chan struct{}           // 클린업 고루틴 중지 신호
+	cleanDone chan struct{}           // 클린업 고루틴 종료
 	config    *config.Config
 	logger    zerolog.Logger
 	mu        sync.RWMutex
 }
 
 // NewManager는 새로운 ProcessManager를 생성합니다
-func NewManager(cfg *config.Config, logger zerolog.Logger) *Manager {
+// store가 nil이 아니면 프로세스 기록을 영구 저장하고, 메모리에 없는 프로세스를 저장소에서 조회합니다
+// eventLog가 nil이 아니면 모든 이벤트를 로그에 기록하여 버퍼 크기와 무관하게 재생할 수 있습니다
+func NewManager(cfg *config.Config, store ProcessStore, eventLog EventLog, logger zerolog.Logger) *Manager {
 	return &Manager{
 		processes: make(map[string]*Process),
+		credits:   make(map[string]int),
+		usage:     make(map[string]*tenantUsage),
+		store:     store,
+		eventLog:  eventLog,
 		config:    cfg,
 		logger:    logger.With().Str("component", "manager").Logger(),
 	}
 }
 
-// Create는 새로운 프로세스를 생성합니다 (상태: pending)
-func (m *Manager) Create(connector, prompt, workDir string) (*Process, error) {
+// Recover는 이전 실행에서 종료되지 않은 기록을 interrupted로 표시하고 오늘의 테넌트 사용량을 다시 집계합니다
+// 서버 시작 시 한 번 호출되며 해당 CLI 프로세스는 이미 사라졌으므로 다시 실행하지 않습니다
+func (m *Manager) Recover() error {
+	if m.store == nil {
+		return nil
+	}
+
+	records, err := m.store.List(ProcessFilter{
+		Statuses: []string{StatusQueued, StatusPending, StatusRunning},
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, record := range records {
+		record.Status = StatusInterrupted
+		record.CompletedAt = &now
+		if record.Result == nil {
+			record.Result = &Result{ExitCode: -1, Error: "Server restarted while the process was active"}
+		}
+
+		if err := m.store.Save(record); err != nil {
+			return err
+		}
+
+		m.logger.Warn().
+			Str("processId", record.ID).
+			Str("connector", record.Connector).
+			Msg("Process marked as interrupted")
+	}
+
+	if len(records) > 0 {
+		m.logger.Info().
+			Int("interrupted", len(records)).
+			Msg("Recovered processes from store")
+	}
+
+	return m.restoreUsage()
+}
+
+// History는 저장소에서 조건에 맞는 프로세스 기록을 조회합니다
+// 메모리에서 정리된 프로세스도 포함되며, 저장소가 없으면 메모리의 프로세스만 반환합니다
+func (m *Manager) History(filter ProcessFilter) ([]ProcessRecord, error) {
+	if m.store != nil {
+		return m.store.List(filter)
+	}
+
+	var records []ProcessRecord
+	for _, process := range m.List() {
+		if record := process.Record(); filter.Matches(record) {
+			records = append(records, record)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].StartedAt.After(records[j].StartedAt)
+	})
+
+	if filter.Offset >= len(records) {
+		return []ProcessRecord{}, nil
+	}
+	records = records[filter.Offset:]
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
+
+// SetDispatcher는 실행 슬롯을 얻은 프로세스를 시작할 함수를 등록합니다
+func (m *Manager) SetDispatcher(dispatch func(*Process)) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.dispatch = dispatch
+}
 
-	// 최대 동시 실행 제한 확인
-	activeCount := 0
-	for _, p := range m.processes {
-		if p.Status == StatusPending || p.Status == StatusRunning {
-			activeCount++
-		}
+// Create는 새로운 프로세스를 생성하고 실행을 요청합니다
+// 실행 슬롯이 있으면 바로 시작(pending)하고, 없으면 대기열에 추가(queued)합니다
+// 대기열이 가득 찬 경우 ErrQueueFull을 반환합니다 (process.maxQueue가 0이면 ErrMaxConcurrent)
+// 테넌트의 일일 실행 수나 실행 시간 제한을 넘었으면 ErrTenantQuota를 감싼 에러를 반환합니다
+// 서버가 종료 중이면 ErrShuttingDown을 반환합니다
+func (m *Manager) Create(spec Spec, connector Connector) (*Process, error) {
+	m.mu.Lock()
+
+	if m.closing {
+		m.mu.Unlock()
+		return nil, ErrShuttingDown
 	}
 
-	if activeCount >= m.config.Process.MaxConcurrent {
+	if spec.Priority == "" {
+		spec.Priority = PriorityInteractive
+	}
+
+	// 테넌트의 일일 사용량 제한 확인
+	now := time.Now()
+	if err := m.checkQuota(spec.Tenant, now); err != nil {
+		m.mu.Unlock()
+
+		m.logger.Warn().
+			Err(err).
+			Str("tenant", spec.Tenant).
+			Msg("Tenant quota exceeded")
+		return nil, err
+	}
+
+	// 전역, 커넥터별, 테넌트별 최대 동시 실행 제한 확인
+	// 빈 슬롯이 있어도 먼저 기다리던 프로세스가 시작할 수 있으면 대기열 뒤에 서서 순서를 지킴
+	activeCount := m.activeCount()
+	queued := m.waitReason(spec.Connector, spec.Tenant) != "" || m.eligibleQueued()
+
+	if queued && len(m.queue) >= m.config.Process.MaxQueue {
+		m.mu.Unlock()
+
+		if m.config.Process.MaxQueue == 0 {
+			m.logger.Warn().
+				Int("active", activeCount).
+				Int("max", m.config.Process.MaxConcurrent).
+				Msg("Max concurrent processes reached")
+			return nil, ErrMaxConcurrent
+		}
+
 		m.logger.Warn().
 			Int("active", activeCount).
-			Int("max", m.config.Process.MaxConcurrent).
-			Msg("Max concurrent processes reached")
-		return nil, ErrMaxConcurrent
+			Int("queued", len(m.queue)).
+			Int("maxQueue", m.config.Process.MaxQueue).
+			Msg("Process queue is full")
+		return nil, ErrQueueFull
 	}
 
 	// 프로세스 ID를 위한 UUID 생성
 	id := uuid.New().String()
 
+	// 요청, 커넥터, 전역 설정 순서로 타임아웃 결정
+	spec.Timeout, spec.IdleTimeout = m.config.ResolveTimeouts(spec.Connector, spec.Timeout, spec.IdleTimeout)
+
 	// 새 프로세스 생성
-	process := NewProcess(id, connector, prompt, workDir, m.config.Process.BufferSize)
+	process := NewProcess(id, spec, m.config.Process.BufferSize)
+	process.connector = connector
+	process.store = m.store
+	process.eventLog = m.eventLog
 
 	// 프로세스 등록
 	m.processes[id] = process
+	if queued {
+		process.Status = StatusQueued
+		m.queue = append(m.queue, process)
+	}
+	if spec.Tenant != "" {
+		m.usageOf(spec.Tenant, now).runs++
+	}
+	queueLength := len(m.queue)
+	dispatch := m.dispatch
+	m.mu.Unlock()
+
+	process.persist()
 
 	m.logger.Info().
 		Str("processId", id).
-		Str("connector", connector).
-		Str("workDir", workDir).
+		Str("connector", spec.Connector).
+		Str("priority", spec.Priority).
+		Str("owner", spec.Owner).
+		Str("tenant", spec.Tenant).
+		Str("workDir", spec.WorkDir).
+		Strs("options", spec.Options.Names()).
+		Dur("timeout", spec.Timeout).
+		Dur("idleTimeout", spec.IdleTimeout).
+		Bool("queued", queued).
+		Int("queueLength", queueLength).
 		Msg("Process created")
 
+	if queued {
+		// 대기열에서 시작할 수 있는 프로세스를 순서대로 시작
+		m.StartQueued()
+	} else if dispatch != nil {
+		dispatch(process)
+	}
+
 	return process, nil
 }
 
-// Get은 ID로 프로세스를 검색합니다
-func (m *Manager) Get(id string) (*Process, error) {
+// finished는 종료된 프로세스의 실행 시간을 메트릭과 테넌트 사용량에 기록합니다
+// Runner가 프로세스 실행을 마칠 때마다 호출합니다
+func (m *Manager) finished(process *Process) {
+	now := time.Now()
+	runtime := process.Runtime(now)
+	record := process.Record()
+	metrics.RunDuration.WithLabelValues(record.Connector, record.Status).Observe(runtime.Seconds())
+
+	if process.Tenant == "" {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usageOf(process.Tenant, now).runtime += runtime
+}
+
+// QueuePosition은 같은 우선순위 클래스 안에서의 대기 순번을 반환합니다 (1부터 시작, 대기 중이 아니면 0)
+func (m *Manager) QueuePosition(id string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var target *Process
+	for _, p := range m.queue {
+		if p.ID == id {
+			target = p
+			break
+		}
+	}
+	if target == nil {
+		return 0
+	}
+
+	position := 0
+	for _, p := range m.queue {
+		if p.Priority == target.Priority {
+			position++
+		}
+		if p == target {
+			break
+		}
+	}
+	return position
+}
+
+// WaitReason은 대기 중인 프로세스가 아직 시작되지 못한 이유를 반환합니다 (대기 중이 아니면 빈 문자열)
+func (m *Manager) WaitReason(id string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, p := range m.queue {
+		if p.ID == id {
+			if reason := m.waitReason(p.Connector, p.Tenant); reason != "" {
+				return reason
+			}
+			return WaitReasonPriority
+		}
+	}
+	return ""
+}
+
+// QueueLength는 대기 중인 프로세스 수를 반환합니다
+func (m *Manager) QueueLength() int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	return len(m.queue)
+}
+
+// StartQueued는 빈 실행 슬롯만큼 대기열의 프로세스를 우선순위 가중치에 따라 시작합니다
+// Runner가 프로세스 실행을 마칠 때마다 호출합니다
+func (m *Manager) StartQueued() {
+	m.mu.Lock()
+	var ready []*Process
+	for !m.closing && m.activeCount() < m.config.Process.MaxConcurrent {
+		process := m.nextQueued()
+		if process == nil {
+			break
+		}
+		m.removeQueued(process.ID)
 
+		// 상태는 잠금 안에서 바꿔 activeCount에 바로 반영하고, 저장소 기록은 잠금 밖에서 수행
+		process.mu.Lock()
+		process.Status = StatusPending
+		process.mu.Unlock()
+		ready = append(ready, process)
+	}
+	remaining := len(m.queue)
+	dispatch := m.dispatch
+	m.mu.Unlock()
+
+	for _, process := range ready {
+		process.persist()
+
+		m.logger.Info().
+			Str("processId", process.ID).
+			Int("remaining", remaining).
+			Msg("Starting queued process")
+
+		if dispatch != nil {
+			dispatch(process)
+		}
+	}
+}
+
+// StatusCount는 커넥터와 상태별 프로세스 수입니다
+type StatusCount struct {
+	Connector string
+	Status    string
+	Count     int
+}
+
+// StatusCounts는 메모리에 있는 프로세스 수를 커넥터와 상태별로 반환합니다
+func (m *Manager) StatusCounts() []StatusCount {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	counts := make(map[StatusCount]int)
+	for _, p := range m.processes {
+		counts[StatusCount{Connector: p.Connector, Status: p.CurrentStatus()}]++
+	}
+
+	result := make([]StatusCount, 0, len(counts))
+	for key, count := range counts {
+		key.Count = count
+		result = append(result, key)
+	}
+	return result
+}
+
+// activeCount는 실행 슬롯을 차지한 (pending, running) 프로세스 수를 반환합니다
+// 호출자가 m.mu를 보유해야 합니다
+func (m *Manager) activeCount() int {
+	count := 0
+	for _, p := range m.processes {
+		if p.holdsSlot() {
+			count++
+		}
+	}
+	return count
+}
+
+// dequeue는 대기열에서 프로세스를 제거합니다 (대기 중이었으면 true)
+func (m *Manager) dequeue(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.removeQueued(id)
+}
+
+// removeQueued는 대기열에서 프로세스를 제거합니다
+// 호출자가 m.mu를 보유해야 합니다
+func (m *Manager) removeQueued(id string) bool {
+	for i, p := range m.queue {
+		if p.ID == id {
+			m.queue = append(m.queue[:i], m.queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Get은 ID로 프로세스를 검색합니다
+// 메모리에 없으면 저장소의 기록을 종료된 읽기 전용 프로세스로 복원합니다
+func (m *Manager) Get(id string) (*Process, error) {
+	m.mu.RLock()
 	process, exists := m.processes[id]
-	if !exists {
+	m.mu.RUnlock()
+
+	if exists {
+		return process, nil
+	}
+
+	return m.load(id)
+}
+
+// load는 저장소에서 프로세스 기록과 이벤트 로그를 읽어 복원합니다
+func (m *Manager) load(id string) (*Process, error) {
+	if m.store == nil {
 		return nil, ErrProcessNotFound
 	}
 
-	return process, nil
+	record, err := m.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return restoreProcess(*record, m.eventLog, m.config.Process.BufferSize)
 }
 
 // List는 모든 프로세스를 반환합니다
@@ -100,12 +438,16 @@ func (m *Manager) List() []*Process {
 
 // Stop은 프로세스를 종료합니다
 func (m *Manager) Stop(id string) error {
-	m.mu.RLock()
-	process, exists := m.processes[id]
-	m.mu.RUnlock()
+	process, err := m.Get(id)
+	if err != nil {
+		return err
+	}
 
-	if !exists {
-		return ErrProcessNotFound
+	// 대기 중인 프로세스는 대기열에서 빼고 실행하지 않음
+	if m.dequeue(id) {
+		m.logger.Info().
+			Str("processId", id).
+			Msg("Queued process cancelled")
 	}
 
 	// 프로세스 중지 (Process.Stop()이 실제 종료를 처리해야 함)
@@ -118,28 +460,43 @@ func (m *Manager) Stop(id string) error {
 	return nil
 }
 
-// Remove는 완료된 프로세스를 제거합니다
+// Remove는 완료된 프로세스를 메모리와 저장소에서 제거합니다
 func (m *Manager) Remove(id string) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	process, exists := m.processes[id]
-	if !exists {
-		return ErrProcessNotFound
+	if exists {
+		// 종료된 (completed, failed, stopped, interrupted) 프로세스만 제거
+		if process.IsActive() {
+			m.mu.Unlock()
+			m.logger.Warn().
+				Str("processId", id).
+				Str("status", process.CurrentStatus()).
+				Msg("Cannot remove active process")
+			return errors.New("cannot remove active process")
+		}
+		delete(m.processes, id)
 	}
+	m.mu.Unlock()
 
-	// completed, failed, 또는 stopped 프로세스만 제거
-	if process.Status != StatusCompleted &&
-		process.Status != StatusFailed &&
-		process.Status != StatusStopped {
-		m.logger.Warn().
-			Str("processId", id).
-			Str("status", process.Status).
-			Msg("Cannot remove active process")
-		return errors.New("cannot remove active process")
+	if m.store != nil {
+		// 메모리에서 이미 정리된 경우 저장소에 기록이 있어야 함
+		if !exists {
+			if _, err := m.store.Get(id); err != nil {
+				return err
+			}
+		}
+		if err := m.store.Delete(id); err != nil {
+			return err
+		}
+	} else if !exists {
+		return ErrProcessNotFound
 	}
 
-	delete(m.processes, id)
+	if m.eventLog != nil {
+		if err := m.eventLog.Delete(id); err != nil {
+			return err
+		}
+	}
 
 	m.logger.Info().
 		Str("processId", id).
@@ -153,19 +510,20 @@ func (m *Manager) Count() int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	activeCount := 0
-	for _, p := range m.processes {
-		if p.Status == StatusPending || p.Status == StatusRunning {
-			activeCount++
-		}
-	}
-
-	return activeCount
+	return m.activeCount()
 }
 
 // StartCleanup은 오래된 완료된 프로세스를 정리하는 고루틴을 시작합니다
 func (m *Manager) StartCleanup() {
+	m.mu.Lock()
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	m.stopClean, m.cleanDone = stop, done
+	m.mu.Unlock()
+
 	go func() {
+		defer close(done)
+
 		ticker := time.NewTicker(m.config.Process.CleanupDelay)
 		defer ticker.Stop()
 
@@ -173,12 +531,112 @@ func (m *Manager) StartCleanup() {
 			Dur("interval", m.config.Process.CleanupDelay).
 			Msg("Process cleanup started")
 
-		for range ticker.C {
-			m.cleanup()
+		for {
+			select {
+			case <-ticker.C:
+				m.cleanup()
+				m.prune()
+			case <-stop:
+				return
+			}
 		}
 	}()
 }
 
+// StopCleanup은 클린업 고루틴을 중지하고 진행 중인 정리가 끝날 때까지 대기합니다
+func (m *Manager) StopCleanup() {
+	m.mu.Lock()
+	stop, done := m.stopClean, m.cleanDone
+	m.stopClean, m.cleanDone = nil, nil
+	m.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+
+	m.logger.Info().Msg("Process cleanup stopped")
+}
+
+// ShuttingDown은 Shutdown이 시작되었는지 여부를 반환합니다
+func (m *Manager) ShuttingDown() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.closing
+}
+
+// Shutdown은 새 프로세스 생성을 막고 모든 프로세스를 종료합니다
+// 대기 중인 프로세스는 시작하지 않고 중지하며, drain이면 실행 중인 프로세스가 끝나기를 ctx가 끝날 때까지 기다립니다
+// 남은 프로세스는 server_shutdown 사유로 중지하므로 구독자는 해당 사유가 담긴 done 이벤트를 받습니다
+func (m *Manager) Shutdown(ctx context.Context, drain bool) {
+	m.mu.Lock()
+	m.closing = true
+	queued := m.queue
+	m.queue = nil
+	m.mu.Unlock()
+
+	m.StopCleanup()
+
+	cause := newStopCause(ReasonServerShutdown, "Server is shutting down")
+	for _, process := range queued {
+		process.stop(cause)
+	}
+
+	active := m.activeProcesses()
+	m.logger.Info().
+		Int("active", len(active)).
+		Int("queued", len(queued)).
+		Bool("drain", drain).
+		Msg("Shutting down processes")
+
+	if drain {
+		// 새 프로세스가 시작되지 않으므로 지금 실행 중인 프로세스만 기다리면 됨
+	wait:
+		for _, process := range active {
+			select {
+			case <-process.done:
+			case <-ctx.Done():
+				break wait
+			}
+		}
+	}
+
+	// 남은 프로세스를 동시에 중지 (각각 stopGracePeriod까지 걸릴 수 있음)
+	var wg sync.WaitGroup
+	stopped := 0
+	for _, process := range active {
+		if !process.IsActive() {
+			continue
+		}
+		stopped++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			process.stop(cause)
+		}()
+	}
+	wg.Wait()
+
+	m.logger.Info().
+		Int("stopped", stopped).
+		Msg("All processes finished")
+}
+
+// activeProcesses는 실행 슬롯을 차지한 프로세스 목록을 반환합니다
+func (m *Manager) activeProcesses() []*Process {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var processes []*Process
+	for _, p := range m.processes {
+		if p.holdsSlot() {
+			processes = append(processes, p)
+		}
+	}
+	return processes
+}
+
 // cleanup은 오래된 완료된 프로세스를 제거합니다
 func (m *Manager) cleanup() {
 	m.mu.Lock()
@@ -190,22 +648,23 @@ func (m *Manager) cleanup() {
 
 	for id, process := range m.processes {
 		// 프로세스가 종료 상태인지 확인
-		if process.Status != StatusCompleted &&
-			process.Status != StatusFailed &&
-			process.Status != StatusStopped {
+		record := process.Record()
+		if record.Status != StatusCompleted &&
+			record.Status != StatusFailed &&
+			record.Status != StatusStopped {
 			continue
 		}
 
 		// 완료 시간이 설정되어 있고 충분히 오래되었는지 확인
-		if process.CompletedAt != nil {
-			age := now.Sub(*process.CompletedAt)
+		if record.CompletedAt != nil {
+			age := now.Sub(*record.CompletedAt)
 			if age >= cleanupThreshold {
 				delete(m.processes, id)
 				removed++
 
 				m.logger.Debug().
 					Str("processId", id).
-					Str("status", process.Status).
+					Str("status", record.Status).
 					Dur("age", age).
 					Msg("Process cleaned up")
 			}
@@ -213,9 +672,85 @@ func (m *Manager) cleanup() {
 	}
 
 	if removed > 0 {
+		metrics.CleanupRemoved.Add(float64(removed))
+
 		m.logger.Info().
 			Int("removed", removed).
 			Int("remaining", len(m.processes)).
 			Msg("Cleanup completed")
 	}
 }
+
+// prune은 보관 기간(store.retention)이 지난 종료된 기록과
+// 보관 기간 또는 전체 크기 제한(store.events)을 넘은 이벤트 로그를 삭제합니다
+func (m *Manager) prune() {
+	m.pruneStore()
+	m.pruneEventLog()
+}
+
+// pruneStore는 저장소에서 보관 기간이 지난 종료된 기록을 삭제합니다
+func (m *Manager) pruneStore() {
+	retention := m.config.Store.Retention
+	if m.store == nil || retention <= 0 {
+		return
+	}
+
+	removed, err := m.store.Prune(time.Now().Add(-retention))
+	if err != nil {
+		m.logger.Error().
+			Err(err).
+			Msg("Failed to prune process store")
+		return
+	}
+
+	if removed > 0 {
+		m.logger.Info().
+			Int("removed", removed).
+			Dur("retention", retention).
+			Msg("Process store pruned")
+	}
+}
+
+// pruneEventLog는 실행 중이 아닌 프로세스의 오래된 이벤트 로그를 삭제합니다
+// store.events.retention이 0이면 store.retention을 따릅니다
+func (m *Manager) pruneEventLog() {
+	if m.eventLog == nil {
+		return
+	}
+
+	cfg := m.config.Store.Events
+	retention := cfg.Retention
+	if retention <= 0 {
+		retention = m.config.Store.Retention
+	}
+	if retention <= 0 && cfg.MaxTotalSize <= 0 {
+		return
+	}
+
+	// 보관 기간이 없으면 크기 제한만 적용
+	before := time.Time{}
+	if retention > 0 {
+		before = time.Now().Add(-retention)
+	}
+
+	removed, err := m.eventLog.Prune(before, cfg.MaxTotalSize, func(id string) bool {
+		m.mu.RLock()
+		process, exists := m.processes[id]
+		m.mu.RUnlock()
+		return exists && process.IsActive()
+	})
+	if err != nil {
+		m.logger.Error().
+			Err(err).
+			Msg("Failed to prune event log")
+		return
+	}
+
+	if removed > 0 {
+		m.logger.Info().
+			Int("removed", removed).
+			Dur("retention", retention).
+			Int64("maxTotalSize", cfg.MaxTotalSize).
+			Msg("Event log pruned")
+	}
+}
@@ -0,0 +1,54 @@
+package runner
+
+import "encoding/json"
+
+// 정규화된 이벤트 종류
+const (
+	KindText       = "text"        // assistant 텍스트
+	KindToolUse    = "tool_use"    // 도구 호출 시작
+	KindToolResult = "tool_result" // 도구 실행 결과
+	KindSystem     = "system"      // 세션 초기화 정보
+	KindUsage      = "usage"       // 토큰 사용량/비용
+	KindResult     = "result"      // 최종 응답
+	KindError      = "error"       // 에러
+)
+
+// Normalized는 커넥터 고유 스키마와 무관하게 UI가 렌더링할 수 있는 공통 이벤트입니다
+// 커넥터는 원본 Data와 함께 하나 이상의 Normalized 항목을 Event에 채웁니다
+type Normalized struct {
+	Kind      string    `json:"kind" example:"text"`
+	Text      string    `json:"text,omitempty" example:"Hello!"`
+	Delta     bool      `json:"delta,omitempty"` // true면 Text를 이전 텍스트 뒤에 이어붙임
+	Tool      *ToolCall `json:"tool,omitempty"`
+	Usage     *Usage    `json:"usage,omitempty"`
+	SessionID string    `json:"sessionId,omitempty"`
+	Model     string    `json:"model,omitempty"`
+	IsError   bool      `json:"isError,omitempty"`
+}
+
+// ToolCall은 도구 호출 또는 그 결과를 나타냅니다
+type ToolCall struct {
+	ID     string          `json:"id,omitempty"`
+	Name   string          `json:"name,omitempty" example:"Bash"`
+	Input  json.RawMessage `json:"input,omitempty" swaggertype:"object"`
+	Output string          `json:"output,omitempty"`
+}
+
+// Usage는 토큰 사용량과 비용을 나타냅니다
+type Usage struct {
+	InputTokens  int64   `json:"inputTokens"`
+	OutputTokens int64   `json:"outputTokens"`
+	CachedTokens int64   `json:"cachedTokens,omitempty"`
+	CostUSD      float64 `json:"costUsd,omitempty"`
+	DurationMs   int64   `json:"durationMs,omitempty"`
+}
+
+// TextDelta는 이어붙일 assistant 텍스트 조각을 생성합니다
+func TextDelta(text string) Normalized {
+	return Normalized{Kind: KindText, Text: text, Delta: true}
+}
+
+// ErrorMessage는 에러 메시지를 생성합니다
+func ErrorMessage(message string) Normalized {
+	return Normalized{Kind: KindError, Text: message, IsError: true}
+}
@@ -0,0 +1,49 @@
+package runner
+
+// 옵션 이름 상수 (커넥터가 지원 옵션을 선언할 때 사용)
+const (
+	OptionModel        = "model"
+	OptionSystemPrompt = "systemPrompt"
+	OptionAllowedTools = "allowedTools"
+	OptionMaxTurns     = "maxTurns"
+	OptionResume       = "resume"
+)
+
+// OptionNames는 알려진 모든 옵션 이름입니다
+var OptionNames = []string{OptionModel, OptionSystemPrompt, OptionAllowedTools, OptionMaxTurns, OptionResume}
+
+// Options는 요청별 커넥터 옵션을 나타냅니다
+// 각 커넥터는 지원하는 옵션을 CLI 플래그로 변환합니다
+type Options struct {
+	Model        string   `json:"model,omitempty" example:"sonnet"`
+	SystemPrompt string   `json:"systemPrompt,omitempty" example:"Answer in Korean."`
+	AllowedTools []string `json:"allowedTools,omitempty" example:"Read,Edit"`
+	MaxTurns     int      `json:"maxTurns,omitempty" example:"5"`
+	Resume       string   `json:"resume,omitempty" example:"7f3c9a2e-1b4d-4c8a-9e6f-2d5b8a1c3e7f"` // 이어서 진행할 CLI 세션 ID
+}
+
+// Names는 값이 지정된 옵션의 이름을 반환합니다
+func (o Options) Names() []string {
+	names := make([]string, 0, len(OptionNames))
+	if o.Model != "" {
+		names = append(names, OptionModel)
+	}
+	if o.SystemPrompt != "" {
+		names = append(names, OptionSystemPrompt)
+	}
+	if len(o.AllowedTools) > 0 {
+		names = append(names, OptionAllowedTools)
+	}
+	if o.MaxTurns != 0 {
+		names = append(names, OptionMaxTurns)
+	}
+	if o.Resume != "" {
+		names = append(names, OptionResume)
+	}
+	return names
+}
+
+// IsZero는 지정된 옵션이 없는지 여부를 반환합니다
+func (o Options) IsZero() bool {
+	return len(o.Names()) == 0
+}
@@ -0,0 +1,30 @@
+//go:build !unix
+
+package runner
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup은 프로세스 그룹을 지원하지 않는 플랫폼에서 아무것도 하지 않습니다
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// terminateProcess는 SIGTERM이 없는 플랫폼이므로 직접 자식 프로세스를 종료합니다
+func terminateProcess(cmd *exec.Cmd) error {
+	return killProcess(cmd)
+}
+
+// killProcess는 직접 자식 프로세스를 강제 종료합니다
+func killProcess(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+
+	err := cmd.Process.Kill()
+	if errors.Is(err, os.ErrProcessDone) {
+		return nil
+	}
+	return err
+}
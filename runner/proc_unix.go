@@ -0,0 +1,42 @@
+//go:build unix
+
+package runner
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup은 CLI를 새로운 프로세스 그룹에서 시작하도록 설정합니다
+// CLI가 생성한 셸과 도구까지 그룹 단위로 시그널을 보낼 수 있습니다
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// terminateProcess는 프로세스 그룹 전체에 SIGTERM을 보냅니다
+func terminateProcess(cmd *exec.Cmd) error {
+	return signalGroup(cmd, syscall.SIGTERM)
+}
+
+// killProcess는 프로세스 그룹 전체에 SIGKILL을 보냅니다
+func killProcess(cmd *exec.Cmd) error {
+	return signalGroup(cmd, syscall.SIGKILL)
+}
+
+// signalGroup은 프로세스 그룹에 시그널을 보냅니다 (이미 종료된 그룹은 무시)
+func signalGroup(cmd *exec.Cmd, sig syscall.Signal) error {
+	if cmd.Process == nil {
+		return nil
+	}
+
+	// 음수 PID는 해당 ID의 프로세스 그룹 전체를 의미
+	err := syscall.Kill(-cmd.Process.Pid, sig)
+	if errors.Is(err, syscall.ESRCH) {
+		return nil
+	}
+	return err
+}
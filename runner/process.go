@@ -2,25 +2,57 @@ package runner
 
 import (
 	"encoding/json"
+	"maps"
 	"os/exec"
 	"sync"
 	"time"
+
+	"cli-runner/pkg/metrics"
 )
 
 // 상태 상수
 const (
-	StatusPending   = "pending"
-	StatusRunning   = "running"
-	StatusCompleted = "completed"
-	StatusFailed    = "failed"
-	StatusStopped   = "stopped"
+	StatusQueued      = "queued" // 실행 슬롯을 기다리는 중
+	StatusPending     = "pending"
+	StatusRunning     = "running"
+	StatusCompleted   = "completed"
+	StatusFailed      = "failed"
+	StatusStopped     = "stopped"
+	StatusInterrupted = "interrupted" // 실행 중 서버가 재시작됨
+)
+
+// 종료 사유 상수 (Result.Reason)
+const (
+	ReasonTimeout        = "timeout"         // 전체 실행 시간 초과
+	ReasonIdleTimeout    = "idle_timeout"    // 이벤트 없이 유휴 시간 초과
+	ReasonUserStop       = "user_stop"       // 사용자 중지 요청
+	ReasonServerShutdown = "server_shutdown" // 서버 종료
+)
+
+// 우선순위 클래스 (대기열 스케줄링에 사용)
+const (
+	PriorityInteractive = "interactive" // 사용자가 기다리는 요청 (기본값)
+	PriorityBatch       = "batch"       // 백그라운드 작업
+)
+
+// Priorities는 우선순위 클래스 목록입니다 (가중치가 같으면 앞쪽이 우선)
+var Priorities = []string{PriorityInteractive, PriorityBatch}
+
+// 대기 사유 (queued 상태인 프로세스가 시작되지 못한 이유)
+const (
+	WaitReasonGlobalLimit    = "global_limit"    // process.maxConcurrent에 도달
+	WaitReasonConnectorLimit = "connector_limit" // connectors.<name>.maxConcurrent에 도달
+	WaitReasonTenantLimit    = "tenant_limit"    // 테넌트의 maxConcurrent에 도달
+	WaitReasonPriority       = "priority"        // 다른 요청이 먼저 시작될 차례
 )
 
 // Event는 프로세스로부터의 스트리밍 이벤트를 나타냅니다
 type Event struct {
-	Type      string          `json:"type"`      // stream, result, error, done
-	Data      json.RawMessage `json:"data"`
-	Timestamp time.Time       `json:"timestamp"`
+	Seq        int             `json:"seq"`  // 프로세스 안에서 1부터 단조 증가하는 순번 (SSE id)
+	Type       string          `json:"type"` // stream, result, error, done
+	Data       json.RawMessage `json:"data"`
+	Normalized []Normalized    `json:"normalized,omitempty"` // 커넥터와 무관한 공통 표현
+	Timestamp  time.Time       `json:"timestamp"`
 }
 
 // Result는 최종 프로세스 결과를 나타냅니다
@@ -28,26 +60,58 @@ type Result struct {
 	ExitCode int             `json:"exitCode"`
 	Output   json.RawMessage `json:"output,omitempty"`
 	Error    string          `json:"error,omitempty"`
+	Reason   string          `json:"reason,omitempty"` // 강제 종료된 경우 timeout, idle_timeout, user_stop, server_shutdown
+}
+
+// Spec은 새로운 프로세스 생성 요청을 나타냅니다
+type Spec struct {
+	Connector string
+	Prompt    string
+	WorkDir   string
+	Options   Options
+	ParentID  string // 이어서 진행하는 경우 이전 프로세스 ID
+	Priority  string // interactive, batch (비어있으면 interactive)
+	Owner     string // 요청한 API 키 이름 (인증을 사용하지 않으면 비어있음)
+	Tenant    string // 키가 속한 테넌트 (비어있으면 테넌트 제한을 적용하지 않음)
+
+	// 0이면 설정의 기본값 사용 (Manager.Create에서 결정)
+	Timeout     time.Duration
+	IdleTimeout time.Duration
 }
 
 // Process는 실행 중인 CLI 프로세스를 나타냅니다
 type Process struct {
-	ID          string     `json:"id"`
-	Connector   string     `json:"connector"`
-	Prompt      string     `json:"prompt"`
-	WorkDir     string     `json:"workDir,omitempty"`
-	Status      string     `json:"status"`
-	StartedAt   time.Time  `json:"startedAt"`
-	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	ID          string        `json:"id"`
+	Connector   string        `json:"connector"`
+	Prompt      string        `json:"prompt"`
+	WorkDir     string        `json:"workDir,omitempty"`
+	Options     Options       `json:"options"`
+	ParentID    string        `json:"parentId,omitempty"`
+	SessionID   string        `json:"sessionId,omitempty"`
+	Priority    string        `json:"priority"`
+	Owner       string        `json:"owner,omitempty"`  // 요청한 API 키 이름
+	Tenant      string        `json:"tenant,omitempty"` // 키가 속한 테넌트
+	Timeout     time.Duration `json:"timeout"`
+	IdleTimeout time.Duration `json:"idleTimeout,omitempty"` // 0이면 유휴 타임아웃 없음
+	Status      string        `json:"status"`
+	StartedAt   time.Time     `json:"startedAt"`
+	RunningAt   *time.Time    `json:"runningAt,omitempty"` // 실행 슬롯을 얻어 CLI를 시작한 시각
+	CompletedAt *time.Time    `json:"completedAt,omitempty"`
 
 	// 내부
 	cmd         *exec.Cmd
-	events      *RingBuffer[Event]
+	connector   Connector          // 대기열에서 시작할 때 사용
+	store       ProcessStore       // nil이면 영구 저장하지 않음
+	eventLog    EventLog           // nil이면 링 버퍼에 남은 이벤트만 재생 가능
+	events      *RingBuffer[Event] // 최근 이벤트 캐시
+	eventCount  int                // 지금까지 추가된 이벤트 수 (다음 이벤트의 offset)
 	result      *Result
-	subscribers map[string]chan Event
-	cancel      func()
+	subscribers map[string]*subscriber
+	delivered   chan struct{}     // 마지막으로 전달 차례를 받은 이벤트의 전달이 끝나면 닫힘
+	cancel      func(cause error) // 실행 context 취소 (원인은 Result.Reason으로 기록)
 	mu          sync.RWMutex
 	done        chan struct{}
+	lastEventAt time.Time // 유휴 타임아웃 판단용 마지막 이벤트 시각
 
 	// result 이벤트 데이터 캐싱 (10분간 보관)
 	resultData   json.RawMessage
@@ -55,84 +119,165 @@ type Process struct {
 }
 
 // NewProcess는 새로운 Process 인스턴스를 생성합니다
-func NewProcess(id, connector, prompt, workDir string, bufferSize int) *Process {
+func NewProcess(id string, spec Spec, bufferSize int) *Process {
 	return &Process{
 		ID:          id,
-		Connector:   connector,
-		Prompt:      prompt,
-		WorkDir:     workDir,
+		Connector:   spec.Connector,
+		Prompt:      spec.Prompt,
+		WorkDir:     spec.WorkDir,
+		Options:     spec.Options,
+		ParentID:    spec.ParentID,
+		Priority:    spec.Priority,
+		Owner:       spec.Owner,
+		Tenant:      spec.Tenant,
+		Timeout:     spec.Timeout,
+		IdleTimeout: spec.IdleTimeout,
 		Status:      StatusPending,
 		StartedAt:   time.Now(),
 		events:      NewRingBuffer[Event](bufferSize),
-		subscribers: make(map[string]chan Event),
+		subscribers: make(map[string]*subscriber),
 		done:        make(chan struct{}),
 	}
 }
 
-// AddEvent는 버퍼에 이벤트를 추가하고 모든 구독자에게 알립니다
+// AddEvent는 이벤트에 순번을 부여하여 로그와 버퍼에 추가하고 모든 구독자에게 알립니다
+// 로그 기록은 순번 순서를 보장하기 위해 잠금 안에서 수행하고,
+// 느린 구독자가 잠금을 오래 잡지 않도록 구독자 전달은 잠금 밖에서 수행합니다
 func (p *Process) AddEvent(event Event) {
 	p.mu.Lock()
 
-	// 버퍼에 추가
+	// 순번은 offset + 1 (Seq가 N인 이벤트 다음부터 재생하려면 offset N부터 읽음)
+	event.Seq = p.eventCount + 1
+
+	// 로그와 버퍼에 추가
+	if p.eventLog != nil {
+		// 기록 실패는 로그 구현에서 로깅하며 실시간 전달에는 영향을 주지 않음
+		_ = p.eventLog.Append(p.ID, event)
+	}
 	p.events.Push(event)
+	p.eventCount++
+	p.lastEventAt = time.Now()
+	metrics.Events.WithLabelValues(p.Connector, event.Type).Inc()
+
+	// 전달 대상과 차례는 잠금 안에서 정함
+	if len(p.subscribers) == 0 {
+		p.mu.Unlock()
+		return
+	}
+	subscribers := maps.Clone(p.subscribers)
+	previous := p.delivered
+	turn := make(chan struct{})
+	p.delivered = turn
+	p.mu.Unlock()
 
-	// 모든 구독자에게 알림
-	for _, ch := range p.subscribers {
-		select {
-		case ch <- event:
-		default:
-			// 구독자 채널이 가득 챠, 블로킹을 방지하기 위해 건너뛰기
-		}
+	// 앞선 이벤트의 전달이 끝난 뒤 전달하여 구독자마다 순번 순서를 유지
+	if previous != nil {
+		<-previous
 	}
+	defer close(turn)
 
-	p.mu.Unlock()
+	// 모든 구독자에게 각자의 backpressure 정책에 따라 전달
+	for subscriberID, sub := range subscribers {
+		p.deliver(subscriberID, sub, event)
+	}
 }
 
-// Subscribe는 이 구독자를 위한 새로운 이벤트 채널을 생성합니다
-func (p *Process) Subscribe(subscriberID string) (<-chan Event, func()) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	// 블로킹을 방지하기 위해 버퍼된 채널 생성
-	ch := make(chan Event, 100)
-	p.subscribers[subscriberID] = ch
+// GetEvents는 모든 버퍼된 이벤트를 반환합니다
+func (p *Process) GetEvents() []Event {
+	return p.events.ToSlice()
+}
 
-	// 채널과 정리 함수 반환
-	cleanup := func() {
-		p.Unsubscribe(subscriberID)
+// EventsFrom은 offset부터 최대 limit개(0이면 끝까지)의 이벤트와 다음 offset을 반환합니다
+// 버퍼에 남아있는 이벤트는 메모리에서, 그보다 오래된 이벤트는 이벤트 로그에서 읽습니다
+// 이벤트 로그가 없으면 버퍼에서 밀려난 이벤트는 건너뛰고 버퍼의 첫 이벤트부터 반환합니다
+func (p *Process) EventsFrom(offset, limit int) ([]Event, int, error) {
+	p.mu.RLock()
+	total := p.eventCount
+	cached := p.events.ToSlice()
+	eventLog := p.eventLog
+	p.mu.RUnlock()
+
+	first := total - len(cached) // 버퍼에 남아있는 첫 이벤트의 offset
+	offset = max(offset, 0)
+	if offset < first && eventLog == nil {
+		offset = first
+	}
+	if offset >= total {
+		return []Event{}, total, nil
 	}
 
-	return ch, cleanup
-}
+	end := total
+	if limit > 0 {
+		end = min(offset+limit, total)
+	}
 
-// Unsubscribe는 구독자를 제거합니다
-func (p *Process) Unsubscribe(subscriberID string) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
+	if offset >= first {
+		return cached[offset-first : end-first], end, nil
+	}
 
-	if ch, exists := p.subscribers[subscriberID]; exists {
-		close(ch)
-		delete(p.subscribers, subscriberID)
+	events, err := eventLog.Read(p.ID, offset, end-offset)
+	if err != nil {
+		return nil, offset, err
 	}
+	return events, offset + len(events), nil
 }
 
-// GetEvents는 모든 버퍼된 이벤트를 반환합니다
-func (p *Process) GetEvents() []Event {
-	return p.events.ToSlice()
+// EventCount는 지금까지 추가된 이벤트 수를 반환합니다
+func (p *Process) EventCount() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.eventCount
 }
 
 // SetStatus는 프로세스 상태를 업데이트합니다
 func (p *Process) SetStatus(status string) {
 	p.mu.Lock()
-	defer p.mu.Unlock()
 	p.Status = status
+	p.mu.Unlock()
+
+	p.persist()
+}
+
+// start는 프로세스를 running으로 전환하고 Stop()이 사용할 cancel을 등록합니다
+// 하나의 잠금 안에서 수행하므로 Stop()은 cancel 없이 running인 프로세스를 볼 수 없습니다
+// 실행 슬롯을 얻은 뒤 시작하기 전에 이미 중지된 프로세스이면 false를 반환합니다
+func (p *Process) start(cancel func(cause error)) bool {
+	p.mu.Lock()
+	if p.Status != StatusPending && p.Status != StatusQueued {
+		p.mu.Unlock()
+		return false
+	}
+	p.Status = StatusRunning
+	now := time.Now()
+	p.RunningAt = &now
+	p.cancel = cancel
+	p.mu.Unlock()
+
+	p.persist()
+	return true
+}
+
+// Runtime은 실행 시간을 반환합니다 (실행 중이면 now까지, 시작하지 않았으면 0)
+func (p *Process) Runtime(now time.Time) time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.RunningAt == nil {
+		return 0
+	}
+	if p.CompletedAt != nil {
+		now = *p.CompletedAt
+	}
+	return now.Sub(*p.RunningAt)
 }
 
 // SetResult는 최종 결과를 설정합니다
 func (p *Process) SetResult(result *Result) {
 	p.mu.Lock()
-	defer p.mu.Unlock()
 	p.result = result
+	p.mu.Unlock()
+
+	p.persist()
 }
 
 // GetResult는 결과를 반환합니다 (완료되지 않았으면 nil)
@@ -142,48 +287,73 @@ func (p *Process) GetResult() *Result {
 	return p.result
 }
 
-// GetStatus는 현재 상태 정보를 반환합니다
-func (p *Process) GetStatus() map[string]interface{} {
+// CurrentStatus는 현재 상태를 반환합니다
+func (p *Process) CurrentStatus() string {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
+	return p.Status
+}
 
-	status := map[string]interface{}{
-		"id":        p.ID,
-		"connector": p.Connector,
-		"prompt":    p.Prompt,
-		"status":    p.Status,
-		"startedAt": p.StartedAt,
-	}
+// holdsSlot은 프로세스가 실행 슬롯을 차지한 (pending, running) 상태인지 여부를 반환합니다
+func (p *Process) holdsSlot() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Status == StatusPending || p.Status == StatusRunning
+}
 
-	if p.WorkDir != "" {
-		status["workDir"] = p.WorkDir
-	}
+// GetStatus는 현재 상태 정보를 반환합니다
+func (p *Process) GetStatus() map[string]interface{} {
+	return p.Record().Summary()
+}
 
-	if p.CompletedAt != nil {
-		status["completedAt"] = p.CompletedAt
-	}
+// Stop은 실행 중인 프로세스를 종료하고 정리가 끝날 때까지 대기합니다
+// 실행 중이면 context 취소로 Runner가 프로세스 그룹을 종료하고 reaping한 뒤 stopped로 전환합니다
+func (p *Process) Stop() {
+	p.stop(newStopCause(ReasonUserStop, "Process stopped by user"))
+}
+
+// stop은 cause를 종료 사유로 기록하며 프로세스를 중지합니다
+func (p *Process) stop(cause error) {
+	p.mu.Lock()
+	cancel := p.cancel
+	if cancel == nil {
+		// 아직 실행되지 않은 프로세스는 바로 중지하고 구독자에게 종료 사유를 알림
+		stopped := p.Status == StatusQueued || p.Status == StatusPending
+		if stopped {
+			p.Status = StatusStopped
+			reason, message := stopReason(cause)
+			p.result = &Result{Error: message, Reason: reason}
+		}
+		p.mu.Unlock()
 
-	if p.result != nil {
-		status["result"] = p.result
+		if stopped {
+			p.AddEvent(newDoneEvent(p))
+		}
+		p.Close()
+		return
 	}
+	p.mu.Unlock()
 
-	return status
+	// context 취소를 트리거하고 Runner가 Close할 때까지 대기
+	cancel(cause)
+	<-p.done
 }
 
-// Stop은 실행 중인 프로세스를 종료합니다
-func (p *Process) Stop() {
+// Close는 모든 구독자에게 알리고 정리합니다
+func (p *Process) Close() {
+	// 설정되지 않았으면 완료 시간 설정
 	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	// cancel 함수가 설정되어 있는 경우 호출 (context 취소를 트리거)
-	if p.cancel != nil {
-		p.cancel()
+	if p.CompletedAt == nil {
+		now := time.Now()
+		p.CompletedAt = &now
 	}
+	p.mu.Unlock()
 
-	// 명령이 실행 중인 경우 종료
-	if p.cmd != nil && p.cmd.Process != nil {
-		p.cmd.Process.Kill()
-	}
+	// done을 기다린 쪽이 저장소와 이벤트 로그에서 최종 상태를 읽을 수 있도록 먼저 반영
+	p.persist()
+	p.finishEventLog()
+
+	p.mu.Lock()
 
 	// done 채널 닫기
 	select {
@@ -193,38 +363,55 @@ func (p *Process) Stop() {
 		close(p.done)
 	}
 
-	// 이미 종료 상태가 아닌 경우 상태 업데이트
-	if p.Status == StatusPending || p.Status == StatusRunning {
-		p.Status = StatusStopped
-		now := time.Now()
-		p.CompletedAt = &now
+	subscribers := p.subscribers
+	p.subscribers = make(map[string]*subscriber)
+	p.mu.Unlock()
+
+	// 모든 구독자 채널 닫기 (알리지 못한 누락 범위가 있으면 가능한 경우 먼저 알림)
+	for _, sub := range subscribers {
+		sub.finish()
 	}
 }
 
-// Close는 모든 구독자에게 알리고 정리합니다
-func (p *Process) Close() {
+// finishEventLog는 이벤트 로그가 설정된 경우 더 이상 쓰지 않는 파일을 닫습니다
+func (p *Process) finishEventLog() {
+	if p.eventLog == nil {
+		return
+	}
+	_ = p.eventLog.Finish(p.ID)
+}
+
+// LastEventAt은 마지막 이벤트가 추가된 시각을 반환합니다 (이벤트가 없으면 zero)
+func (p *Process) LastEventAt() time.Time {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.lastEventAt
+}
+
+// SetSessionID는 CLI 출력에서 추출한 세션 ID를 저장합니다
+func (p *Process) SetSessionID(sessionID string) {
 	p.mu.Lock()
-	defer p.mu.Unlock()
+	changed := p.SessionID != sessionID
+	p.SessionID = sessionID
+	p.mu.Unlock()
 
-	// done 채널 닫기
-	select {
-	case <-p.done:
-		// 이미 닫힘
-	default:
-		close(p.done)
+	if changed {
+		p.persist()
 	}
+}
 
-	// 모든 구독자 채널 닫기
-	for subscriberID, ch := range p.subscribers {
-		close(ch)
-		delete(p.subscribers, subscriberID)
-	}
+// GetSessionID는 저장된 세션 ID를 반환합니다 (없으면 빈 문자열)
+func (p *Process) GetSessionID() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.SessionID
+}
 
-	// 설정되지 않았으면 완료 시간 설정
-	if p.CompletedAt == nil {
-		now := time.Now()
-		p.CompletedAt = &now
-	}
+// IsActive는 프로세스가 아직 종료되지 않았는지 여부를 반환합니다
+func (p *Process) IsActive() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Status == StatusQueued || p.Status == StatusPending || p.Status == StatusRunning
 }
 
 // SetResultData는 result 이벤트 데이터를 저장합니다 (10분간 보관)
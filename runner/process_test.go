@@ -0,0 +1,68 @@
+package runner
+
+import "testing"
+
+// closeOrderStore는 Save가 호출될 때 done 채널이 이미 닫혔는지 기록합니다
+type closeOrderStore struct {
+	ProcessStore
+	process *Process
+	saves   []ProcessRecord
+	late    bool // done이 닫힌 뒤 Save가 호출됨
+}
+
+func (s *closeOrderStore) Save(record ProcessRecord) error {
+	s.saves = append(s.saves, record)
+	s.late = s.late || isDone(s.process)
+	return nil
+}
+
+// closeOrderLog는 Finish가 호출될 때 done 채널이 이미 닫혔는지 기록합니다
+type closeOrderLog struct {
+	EventLog
+	process  *Process
+	finished bool
+	late     bool
+}
+
+func (l *closeOrderLog) Finish(processID string) error {
+	l.finished = true
+	l.late = l.late || isDone(l.process)
+	return nil
+}
+
+// isDone은 프로세스의 done 채널이 닫혔는지 여부를 반환합니다
+func isDone(p *Process) bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// done을 기다린 쪽이 최종 상태를 읽을 수 있도록 저장과 이벤트 로그 정리가 done보다 먼저 끝나야 함
+func TestCloseOrder(t *testing.T) {
+	p := NewProcess("p1", Spec{Connector: "test"}, 10)
+	store := &closeOrderStore{process: p}
+	eventLog := &closeOrderLog{process: p}
+	p.store = store
+	p.eventLog = eventLog
+
+	p.mu.Lock()
+	p.Status = StatusCompleted
+	p.mu.Unlock()
+	p.Close()
+
+	if !isDone(p) {
+		t.Fatal("done is still open after Close")
+	}
+	if len(store.saves) == 0 || store.late {
+		t.Fatalf("saved %d times, after done: %v; want a save before done", len(store.saves), store.late)
+	}
+	if last := store.saves[len(store.saves)-1]; last.Status != StatusCompleted || last.CompletedAt == nil {
+		t.Errorf("last saved record = %+v, want completed with completedAt", last)
+	}
+	if !eventLog.finished || eventLog.late {
+		t.Errorf("event log finished: %v, after done: %v; want finished before done", eventLog.finished, eventLog.late)
+	}
+}
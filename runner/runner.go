@@ -2,40 +2,109 @@ package runner
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os/exec"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
+
+	"cli-runner/pkg/metrics"
 )
 
 // Connector는 다양한 CLI 도구를 위한 인터페이스입니다
 type Connector interface {
 	Name() string
-	BuildCommand(prompt string) *exec.Cmd
+	BuildCommand(prompt string, opts Options) *exec.Cmd
 	ParseLine(line string) (*Event, error)
 }
 
+// OutputParser는 단일 실행의 출력을 해석합니다 (실행 간 상태를 공유하지 않음)
+type OutputParser interface {
+	ParseLine(line string) (*Event, error)
+	// Finish는 CLI가 종료된 뒤 호출되며 남아있는 이벤트를 반환합니다
+	// success는 CLI가 정상 종료(exit 0)했는지 여부이며, 중지되거나 실패한 실행이면 false입니다
+	Finish(success bool) ([]*Event, error)
+}
+
+// ParserFactory는 실행마다 별도의 파서 상태가 필요한 커넥터가 구현합니다
+type ParserFactory interface {
+	NewParser() OutputParser
+}
+
+// SessionExtractor는 출력 이벤트에서 CLI 세션 ID를 추출할 수 있는 커넥터가 구현합니다
+// 추출된 세션 ID는 이후 resume 옵션으로 대화를 이어가는 데 사용됩니다
+type SessionExtractor interface {
+	SessionID(event Event) string
+}
+
+// StdinPrompter는 프롬프트를 argv 대신 stdin으로 전달하는 커넥터가 구현합니다
+// argv 길이 제한(ARG_MAX)을 피하고 ps 출력과 로그에 프롬프트가 노출되지 않습니다
+type StdinPrompter interface {
+	PromptViaStdin() bool
+}
+
+// lineParser는 ParseLine만 제공하는 커넥터를 OutputParser로 감쌉니다
+type lineParser struct {
+	connector Connector
+}
+
+func (p lineParser) ParseLine(line string) (*Event, error) {
+	return p.connector.ParseLine(line)
+}
+
+func (p lineParser) Finish(bool) ([]*Event, error) {
+	return nil, nil
+}
+
+// newOutputParser는 커넥터에 맞는 파서를 반환합니다
+func newOutputParser(connector Connector) OutputParser {
+	if factory, ok := connector.(ParserFactory); ok {
+		return factory.NewParser()
+	}
+	return lineParser{connector: connector}
+}
+
+// errStoppedBeforeStart는 실행 슬롯을 얻은 프로세스가 시작되기 전에 중지되었음을 나타냅니다
+var errStoppedBeforeStart = errors.New("process was stopped before it started")
+
 // Runner는 프로세스 실행을 처리합니다
 type Runner struct {
 	manager *Manager
 	logger  zerolog.Logger
 }
 
-// NewRunner는 새로운 Runner를 생성합니다
+// NewRunner는 새로운 Runner를 생성하고 매니저의 디스패처로 등록합니다
+// 매니저가 실행 슬롯을 배정한 프로세스는 Runner가 시작합니다
 func NewRunner(manager *Manager, logger zerolog.Logger) *Runner {
-	return &Runner{
+	r := &Runner{
 		manager: manager,
 		logger:  logger.With().Str("component", "runner").Logger(),
 	}
+	manager.SetDispatcher(r.dispatch)
+	return r
+}
+
+// dispatch는 실행 슬롯을 얻은 프로세스를 생성 시 지정된 커넥터로 시작합니다
+func (r *Runner) dispatch(process *Process) {
+	if err := r.Spawn(process, process.connector); err != nil {
+		// 시작 전에 중지된 프로세스는 Stop()이 이미 종료 처리함
+		if !errors.Is(err, errStoppedBeforeStart) {
+			r.handleError(process, err)
+		}
+		r.manager.StartQueued()
+	}
 }
 
 // Spawn은 고루틴에서 프로세스 실행을 시작합니다
-// 시작 후 즉시 반환합니다
-func (r *Runner) Spawn(process *Process, connector Connector, timeout time.Duration) error {
+// 프로세스의 Timeout/IdleTimeout이 적용되며 시작 후 즉시 반환합니다
+func (r *Runner) Spawn(process *Process, connector Connector) error {
 	if process == nil {
 		return fmt.Errorf("process cannot be nil")
 	}
@@ -46,23 +115,39 @@ func (r *Runner) Spawn(process *Process, connector Connector, timeout time.Durat
 	r.logger.Info().
 		Str("processId", process.ID).
 		Str("connector", connector.Name()).
-		Dur("timeout", timeout).
+		Dur("timeout", process.Timeout).
+		Dur("idleTimeout", process.IdleTimeout).
 		Msg("Spawning process")
 
-	// 초기 상태를 running으로 설정
-	process.SetStatus(StatusRunning)
-
-	// 타임아웃으로 context 생성
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	// 취소 원인(user_stop, timeout, idle_timeout)을 기록할 수 있는 context 생성
+	ctx, cancel := context.WithCancelCause(context.Background())
 
-	// Stop() 지원을 위해 프로세스에 cancel 함수 저장
-	process.mu.Lock()
-	process.cancel = cancel
-	process.mu.Unlock()
+	// running 전환과 cancel 등록을 함께 수행하여 이후의 Stop()이 항상 context를 취소하도록 함
+	if !process.start(cancel) {
+		cancel(nil)
+		return errStoppedBeforeStart
+	}
+	metrics.QueueWait.WithLabelValues(process.Connector, process.Priority).Observe(time.Since(process.StartedAt).Seconds())
+
+	stopTimeout := func() bool { return false }
+	if process.Timeout > 0 {
+		timer := time.AfterFunc(process.Timeout, func() {
+			cancel(newStopCause(ReasonTimeout, "Process timed out after "+process.Timeout.String()))
+		})
+		stopTimeout = timer.Stop
+	}
 
 	// 고루틴에서 실행 시작
 	go func() {
-		defer cancel()
+		// 실행이 끝나면 실행 시간을 기록하고 빈 슬롯으로 대기 중인 프로세스 시작
+		defer r.manager.StartQueued()
+		defer r.manager.finished(process)
+		defer cancel(nil)
+		defer stopTimeout()
+
+		if process.IdleTimeout > 0 {
+			go watchIdle(ctx, process, process.IdleTimeout, cancel)
+		}
 		r.run(ctx, process, connector)
 	}()
 
@@ -79,8 +164,8 @@ func (r *Runner) run(ctx context.Context, process *Process, connector Connector)
 		Msg("Running process")
 
 	// 명령 구축
-	cmd := connector.BuildCommand(process.Prompt)
-	cmd.Stderr = cmd.Stdout // 통합 스트리밍을 위해 stderr를 stdout에 병합
+	cmd := connector.BuildCommand(process.Prompt, process.Options)
+	setProcessGroup(cmd) // 중지 시 CLI가 생성한 하위 프로세스까지 종료하기 위함
 
 	// working directory 설정
 	if process.WorkDir != "" {
@@ -99,6 +184,23 @@ func (r *Runner) run(ctx context.Context, process *Process, connector Connector)
 		return
 	}
 
+	// stderr는 JSON 파싱 대상이 아니므로 별도의 파이프로 읽음
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		r.handleError(process, fmt.Errorf("failed to create stderr pipe: %w", err))
+		return
+	}
+
+	// stdin으로 프롬프트를 전달하는 커넥터인 경우 stdin 파이프 생성
+	var stdin io.WriteCloser
+	if prompter, ok := connector.(StdinPrompter); ok && prompter.PromptViaStdin() {
+		stdin, err = cmd.StdinPipe()
+		if err != nil {
+			r.handleError(process, fmt.Errorf("failed to create stdin pipe: %w", err))
+			return
+		}
+	}
+
 	// 명령 시작
 	if err := cmd.Start(); err != nil {
 		r.handleError(process, fmt.Errorf("failed to start command: %w", err))
@@ -111,53 +213,80 @@ func (r *Runner) run(ctx context.Context, process *Process, connector Connector)
 		Str("connector", connector.Name()).
 		Int("pid", cmd.Process.Pid).
 		Str("command", cmd.Path).
-		Strs("args", cmd.Args).
+		Int("argCount", len(cmd.Args)-1). // 인자에 프롬프트가 포함될 수 있으므로 개수만 기록
 		Str("workDir", process.WorkDir).
-		Str("prompt", process.Prompt).
+		Bool("stdinPrompt", stdin != nil).
+		Int("promptSize", len(process.Prompt)).
 		Msg("CLI process started")
 
-	// 별도의 고루틴에서 출력 스트리밍
+	// 큰 프롬프트를 쓰는 동안 출력 파이프가 막히지 않도록 별도의 고루틴에서 전달
+	if stdin != nil {
+		go r.writePrompt(stdin, process)
+	}
+
+	// 별도의 고루틴에서 stdout/stderr 스트리밍
+	var stderrTail *RingBuffer[string]
+	if lines := r.manager.config.Process.StderrTailLines; lines > 0 {
+		stderrTail = NewRingBuffer[string](lines)
+	}
+	parser := newOutputParser(connector)
+	var streams sync.WaitGroup
+	streams.Add(2)
+	go func() {
+		defer streams.Done()
+		r.streamOutput(stdout, process, connector, parser)
+	}()
+	go func() {
+		defer streams.Done()
+		r.streamStderr(stderr, process, stderrTail)
+	}()
+
 	streamDone := make(chan struct{})
 	go func() {
-		r.streamOutput(stdout, process, connector)
+		streams.Wait()
 		close(streamDone)
 	}()
 
 	// 명령 완료 또는 context 취소를 대기
+	// Wait는 파이프를 닫으므로 출력을 모두 읽은 뒤에 호출해야 함
 	cmdDone := make(chan error, 1)
 	go func() {
+		<-streamDone
 		cmdDone <- cmd.Wait()
 	}()
 
 	var cmdErr error
 	select {
 	case <-ctx.Done():
-		// Context가 취소됨 (타임아웃 또는 수동 중지)
+		// Context가 취소됨 (타임아웃, 유휴 타임아웃 또는 수동 중지)
+		reason, message := causeOf(ctx)
 		r.logger.Warn().
 			Str("processId", process.ID).
-			Err(ctx.Err()).
+			Str("reason", reason).
 			Msg("Process context cancelled")
 
-		// 프로세스 종료
-		if cmd.Process != nil {
-			if err := cmd.Process.Kill(); err != nil {
-				r.logger.Error().
-					Str("processId", process.ID).
-					Err(err).
-					Msg("Failed to kill process")
-			}
-		}
-
-		// 명령이 종료되기를 대기
-		<-cmdDone
+		// 프로세스 그룹 종료 후 reaping이 끝난 뒤에 상태 변경
+		cmdErr = r.terminate(process, cmd, cmdDone)
+		r.finishOutput(process, connector, parser, false)
 		process.SetStatus(StatusStopped)
 
+		// 결과 설정 (종료 사유 기록)
+		exitCode := 0
+		if cmdErr != nil {
+			exitCode = getExitCode(cmdErr)
+		}
+		process.SetResult(&Result{
+			ExitCode: exitCode,
+			Error:    message,
+			Reason:   reason,
+		})
+
 		// 에러 이벤트 전송
-		r.sendErrorEvent(process, "Process stopped or timed out")
+		r.sendErrorEvent(process, message)
 
 	case cmdErr = <-cmdDone:
-		// 명령이 정상적으로 완료됨
-		<-streamDone // 스트림이 종료되기를 대기
+		// 명령이 정상적으로 완료됨 (스트림은 이미 종료됨)
+		r.finishOutput(process, connector, parser, cmdErr == nil)
 
 		if cmdErr != nil {
 			duration := time.Since(startTime)
@@ -170,20 +299,21 @@ func (r *Runner) run(ctx context.Context, process *Process, connector Connector)
 				Err(cmdErr).
 				Int("exitCode", exitCode).
 				Dur("duration", duration).
-				Str("prompt", process.Prompt).
+				Int("promptSize", len(process.Prompt)).
 				Msg("CLI process failed")
 
 			process.SetStatus(StatusFailed)
 
-			// 결과 설정
+			// 결과 설정 (진단을 위해 stderr 마지막 라인 포함)
+			errorMsg := exitErrorMessage(cmdErr, stderrTail)
 			result := &Result{
 				ExitCode: exitCode,
-				Error:    cmdErr.Error(),
+				Error:    errorMsg,
 			}
 			process.SetResult(result)
 
 			// 에러 이벤트 전송
-			r.sendErrorEvent(process, cmdErr.Error())
+			r.sendErrorEvent(process, errorMsg)
 		} else {
 			duration := time.Since(startTime)
 
@@ -193,7 +323,7 @@ func (r *Runner) run(ctx context.Context, process *Process, connector Connector)
 				Str("connector", connector.Name()).
 				Int("exitCode", 0).
 				Dur("duration", duration).
-				Str("prompt", process.Prompt).
+				Int("promptSize", len(process.Prompt)).
 				Msg("CLI process completed successfully")
 
 			process.SetStatus(StatusCompleted)
@@ -219,7 +349,7 @@ func (r *Runner) run(ctx context.Context, process *Process, connector Connector)
 	logEvent := r.logger.Info().
 		Str("processId", process.ID).
 		Str("connector", connector.Name()).
-		Str("status", process.Status).
+		Str("status", process.CurrentStatus()).
 		Dur("totalDuration", duration)
 
 	if result != nil {
@@ -232,20 +362,59 @@ func (r *Runner) run(ctx context.Context, process *Process, connector Connector)
 	logEvent.Msg("CLI process execution finished")
 }
 
+// terminate는 프로세스 그룹에 SIGTERM을 보내고 유예 시간 안에 종료되지 않으면 SIGKILL을 보냅니다
+// 명령이 reaping될 때까지 대기한 뒤 Wait 결과를 반환합니다
+func (r *Runner) terminate(process *Process, cmd *exec.Cmd, cmdDone <-chan error) error {
+	grace := r.manager.config.Process.StopGracePeriod
+
+	if grace > 0 {
+		if err := terminateProcess(cmd); err != nil {
+			r.logger.Error().
+				Str("processId", process.ID).
+				Err(err).
+				Msg("Failed to send SIGTERM to process group")
+		}
+
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+
+		select {
+		case err := <-cmdDone:
+			return err
+		case <-timer.C:
+			r.logger.Warn().
+				Str("processId", process.ID).
+				Dur("gracePeriod", grace).
+				Msg("Process did not exit within grace period, killing")
+		}
+	}
+
+	if err := killProcess(cmd); err != nil {
+		r.logger.Error().
+			Str("processId", process.ID).
+			Err(err).
+			Msg("Failed to kill process group")
+	}
+
+	return <-cmdDone
+}
+
 // streamOutput은 리더로부터 읽고 이벤트를 전송합니다
-func (r *Runner) streamOutput(reader io.Reader, process *Process, connector Connector) {
+// 파서에 남은 이벤트는 종료 상태를 안 뒤 finishOutput에서 전송합니다
+func (r *Runner) streamOutput(reader io.Reader, process *Process, connector Connector, parser OutputParser) {
 	scanner := bufio.NewScanner(reader)
 
 	// 긴 라인을 위해 더 큰 버퍼 크기 설정 (기본값은 64KB, 필요한 경우 증가)
 	const maxCapacity = 1024 * 1024 // 1MB
 	buf := make([]byte, maxCapacity)
 	scanner.Buffer(buf, maxCapacity)
+	scanner.Split(scanLines)
 
 	for scanner.Scan() {
 		line := scanner.Text()
 
 		// 커녅터를 사용하여 라인 파싱
-		event, err := connector.ParseLine(line)
+		event, err := parser.ParseLine(line)
 		if err != nil {
 			r.logger.Warn().
 				Str("processId", process.ID).
@@ -260,45 +429,175 @@ func (r *Runner) streamOutput(reader io.Reader, process *Process, connector Conn
 			continue
 		}
 
-		// 타임스탬프 설정
-		event.Timestamp = time.Now()
+		r.emitEvent(process, connector, event)
+	}
 
-		// 프로세스 버퍼에 이벤트를 추가하고 구독자에게 알림
-		process.AddEvent(*event)
+	if err := scanner.Err(); err != nil {
+		r.logger.Error().
+			Str("processId", process.ID).
+			Err(err).
+			Msg("Error reading output")
+	}
+}
 
-		// result 이벤트인 경우 데이터를 10분간 메모리에 저장 (방어 로직)
-		if event.Type == "result" {
-			process.SetResultData(event.Data)
-			r.logger.Info().
-				Str("processId", process.ID).
-				Str("connector", connector.Name()).
-				Int("dataSize", len(event.Data)).
-				Msg("Result data cached for 10 minutes")
+// finishOutput은 파서에 남아있는 이벤트를 전송합니다 (예: 단일 JSON 출력)
+// 출력을 모두 읽은 뒤 (cmd.Wait가 반환된 뒤) 호출해야 합니다
+func (r *Runner) finishOutput(process *Process, connector Connector, parser OutputParser, success bool) {
+	events, err := parser.Finish(success)
+	if err != nil {
+		r.logger.Warn().
+			Str("processId", process.ID).
+			Err(err).
+			Msg("Failed to parse remaining output")
+	}
+	for _, event := range events {
+		if event != nil {
+			r.emitEvent(process, connector, event)
 		}
+	}
+}
 
-		// CLI 응답 이벤트 로깅
-		logEvent := r.logger.Info().
-			Str("processId", process.ID).
-			Str("connector", connector.Name()).
-			Str("eventType", event.Type).
-			Time("eventTime", event.Timestamp)
+// streamStderr는 stderr 라인을 stderr 이벤트로 전송하고 마지막 라인을 tail에 보관합니다
+// tail이 nil이면 보관하지 않습니다
+func (r *Runner) streamStderr(reader io.Reader, process *Process, tail *RingBuffer[string]) {
+	scanner := bufio.NewScanner(reader)
 
-		// 이벤트 데이터 내용 추가 (최대 500자로 제한)
-		dataStr := string(event.Data)
-		if len(dataStr) > 500 {
-			logEvent = logEvent.Str("data", dataStr[:500]+"... (truncated)")
-		} else {
-			logEvent = logEvent.Str("data", dataStr)
+	const maxCapacity = 1024 * 1024 // 1MB
+	buf := make([]byte, maxCapacity)
+	scanner.Buffer(buf, maxCapacity)
+	scanner.Split(scanLines)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		if line == "" {
+			continue
 		}
 
-		logEvent.Msg("CLI response event")
+		if tail != nil {
+			tail.Push(line)
+		}
+
+		data, _ := json.Marshal(map[string]string{"text": line})
+		process.AddEvent(Event{
+			Type:      "stderr",
+			Data:      data,
+			Timestamp: time.Now(),
+		})
+
+		r.logger.Debug().
+			Str("processId", process.ID).
+			Str("line", truncate(line, 500)).
+			Msg("CLI stderr")
 	}
 
 	if err := scanner.Err(); err != nil {
 		r.logger.Error().
 			Str("processId", process.ID).
 			Err(err).
-			Msg("Error reading output")
+			Msg("Error reading stderr")
+	}
+}
+
+// exitErrorMessage는 종료 에러에 보관된 stderr 마지막 라인을 덧붙입니다
+func exitErrorMessage(err error, stderrTail *RingBuffer[string]) string {
+	if stderrTail == nil {
+		return err.Error()
+	}
+
+	lines := stderrTail.ToSlice()
+	if len(lines) == 0 {
+		return err.Error()
+	}
+	return err.Error() + ": " + strings.Join(lines, "\n")
+}
+
+// scanLines는 \n, \r\n 외에 단독 \r도 라인 구분자로 취급하는 bufio.SplitFunc입니다
+// 텍스트 CLI가 진행 표시를 \r로 덮어쓰는 경우에도 라인이 무한히 길어지지 않습니다
+// (JSON 문자열 안의 CR은 이스케이프되므로 JSON 라인에는 영향이 없음)
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		// \r 다음 바이트를 확인해야 \r\n인지 알 수 있음
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+		// 더 많은 데이터 요청
+		return 0, nil, nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	// 더 많은 데이터 요청
+	return 0, nil, nil
+}
+
+// emitEvent는 파싱된 이벤트를 프로세스에 추가하고 로깅합니다
+func (r *Runner) emitEvent(process *Process, connector Connector, event *Event) {
+	// 타임스탬프 설정
+	event.Timestamp = time.Now()
+
+	// 프로세스 버퍼에 이벤트를 추가하고 구독자에게 알림
+	process.AddEvent(*event)
+
+	// 세션 ID 기록 (대화 이어가기용)
+	if extractor, ok := connector.(SessionExtractor); ok {
+		if sessionID := extractor.SessionID(*event); sessionID != "" {
+			process.SetSessionID(sessionID)
+		}
+	}
+
+	// result 이벤트인 경우 데이터를 10분간 메모리에 저장 (방어 로직)
+	if event.Type == "result" {
+		process.SetResultData(event.Data)
+		r.logger.Info().
+			Str("processId", process.ID).
+			Str("connector", connector.Name()).
+			Int("dataSize", len(event.Data)).
+			Msg("Result data cached for 10 minutes")
+	}
+
+	// CLI 응답 이벤트 로깅
+	logEvent := r.logger.Info().
+		Str("processId", process.ID).
+		Str("connector", connector.Name()).
+		Str("eventType", event.Type).
+		Time("eventTime", event.Timestamp)
+
+	// 이벤트 데이터 내용 추가 (최대 500자로 제한)
+	logEvent = logEvent.Str("data", truncate(string(event.Data), 500))
+
+	logEvent.Msg("CLI response event")
+}
+
+// writePrompt는 프롬프트를 stdin에 쓰고 닫아서 CLI에 입력의 끝을 알립니다
+func (r *Runner) writePrompt(stdin io.WriteCloser, process *Process) {
+	if _, err := io.WriteString(stdin, process.Prompt); err != nil {
+		// CLI가 입력을 모두 읽기 전에 종료된 경우 (EPIPE)
+		r.logger.Warn().
+			Str("processId", process.ID).
+			Err(err).
+			Msg("Failed to write prompt to stdin")
+	}
+
+	if err := stdin.Close(); err != nil {
+		r.logger.Debug().
+			Str("processId", process.ID).
+			Err(err).
+			Msg("Failed to close stdin")
 	}
 }
 
@@ -335,9 +634,10 @@ func (r *Runner) sendErrorEvent(process *Process, errorMsg string) {
 	})
 
 	event := Event{
-		Type:      "error",
-		Data:      errorData,
-		Timestamp: time.Now(),
+		Type:       "error",
+		Data:       errorData,
+		Normalized: []Normalized{ErrorMessage(errorMsg)},
+		Timestamp:  time.Now(),
 	}
 
 	process.AddEvent(event)
@@ -345,20 +645,31 @@ func (r *Runner) sendErrorEvent(process *Process, errorMsg string) {
 
 // sendDoneEvent는 구독자에게 done 이벤트를 전송합니다
 func (r *Runner) sendDoneEvent(process *Process) {
+	process.AddEvent(newDoneEvent(process))
+}
+
+// newDoneEvent는 프로세스의 최종 상태와 결과를 담은 done 이벤트를 생성합니다
+func newDoneEvent(process *Process) Event {
 	result := process.GetResult()
 	doneData, _ := json.Marshal(map[string]interface{}{
 		"processId": process.ID,
-		"status":    process.Status,
+		"status":    process.CurrentStatus(),
 		"result":    result,
 	})
 
-	event := Event{
+	return Event{
 		Type:      "done",
 		Data:      doneData,
 		Timestamp: time.Now(),
 	}
+}
 
-	process.AddEvent(event)
+// truncate는 로그에 남길 문자열을 최대 길이로 자릅니다
+func truncate(s string, limit int) string {
+	if len(s) > limit {
+		return s[:limit] + "... (truncated)"
+	}
+	return s
 }
 
 // getExitCode는 명령 에러로부터 종료 코드를 추출합니다
@@ -0,0 +1,97 @@
+package runner
+
+// 대기열 스케줄링 (모든 함수는 호출자가 Manager.mu를 보유해야 합니다)
+
+// waitReason은 해당 커넥터와 테넌트의 프로세스를 지금 시작할 수 없는 이유를 반환합니다
+// 시작할 수 있으면 빈 문자열을 반환합니다
+func (m *Manager) waitReason(connector, tenant string) string {
+	if m.activeCount() >= m.config.Process.MaxConcurrent {
+		return WaitReasonGlobalLimit
+	}
+
+	limit := m.config.Connectors[connector].MaxConcurrent
+	if limit > 0 && m.connectorActiveCount(connector) >= limit {
+		return WaitReasonConnectorLimit
+	}
+
+	if tenant != "" {
+		limit := m.config.Tenants.Quota(tenant).MaxConcurrent
+		if limit > 0 && m.tenantActiveCount(tenant) >= limit {
+			return WaitReasonTenantLimit
+		}
+	}
+
+	return ""
+}
+
+// eligibleQueued는 제한에 걸리지 않아 바로 시작할 수 있는 대기 프로세스가 있는지 여부를 반환합니다
+func (m *Manager) eligibleQueued() bool {
+	for _, p := range m.queue {
+		if m.waitReason(p.Connector, p.Tenant) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// connectorActiveCount는 실행 슬롯을 차지한 해당 커넥터의 프로세스 수를 반환합니다
+func (m *Manager) connectorActiveCount(connector string) int {
+	count := 0
+	for _, p := range m.processes {
+		if p.Connector == connector && p.holdsSlot() {
+			count++
+		}
+	}
+	return count
+}
+
+// nextQueued는 다음에 시작할 대기 프로세스를 선택합니다 (없으면 nil)
+// 우선순위 클래스마다 커넥터와 테넌트 제한에 걸리지 않은 가장 오래된 프로세스를 후보로 하고,
+// 후보가 있는 클래스 사이에서는 가중치에 비례하도록 smooth weighted round robin으로 선택합니다
+func (m *Manager) nextQueued() *Process {
+	candidates := make(map[string]*Process, len(Priorities))
+	for _, p := range m.queue {
+		if _, ok := candidates[p.Priority]; ok {
+			continue
+		}
+		if m.waitReason(p.Connector, p.Tenant) != "" {
+			continue
+		}
+		candidates[p.Priority] = p
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	total := 0
+	selected := ""
+	for _, priority := range Priorities {
+		if _, ok := candidates[priority]; !ok {
+			continue
+		}
+
+		weight := m.priorityWeight(priority)
+		m.credits[priority] += weight
+		total += weight
+
+		if selected == "" || m.credits[priority] > m.credits[selected] {
+			selected = priority
+		}
+	}
+	m.credits[selected] -= total
+
+	return candidates[selected]
+}
+
+// priorityWeight는 우선순위 클래스의 가중치를 반환합니다 (최소 1)
+func (m *Manager) priorityWeight(priority string) int {
+	weight := 1
+	switch priority {
+	case PriorityInteractive:
+		weight = m.config.Process.PriorityWeights.Interactive
+	case PriorityBatch:
+		weight = m.config.Process.PriorityWeights.Batch
+	}
+	return max(weight, 1)
+}
@@ -0,0 +1,295 @@
+package runner
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"cli-runner/config"
+)
+
+// testProcess는 테스트 매니저에 등록할 프로세스입니다
+type testProcess struct {
+	id        string
+	priority  string
+	connector string
+	tenant    string
+}
+
+func TestNextQueued(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights config.PriorityWeights
+		active  []testProcess // 실행 슬롯을 차지한 (running) 프로세스
+		queue   []testProcess // 대기열 순서
+		want    []string      // nextQueued가 선택하는 순서 (선택할 수 없으면 끝남)
+	}{
+		{
+			name:    "weighted ordering",
+			weights: config.PriorityWeights{Interactive: 3, Batch: 1},
+			queue: []testProcess{
+				{id: "b1", priority: PriorityBatch}, {id: "b2", priority: PriorityBatch},
+				{id: "i1", priority: PriorityInteractive}, {id: "i2", priority: PriorityInteractive},
+				{id: "i3", priority: PriorityInteractive}, {id: "i4", priority: PriorityInteractive},
+			},
+			want: []string{"i1", "i2", "b1", "i3", "i4", "b2"},
+		},
+		{
+			name:    "equal weights alternate",
+			weights: config.PriorityWeights{Interactive: 1, Batch: 1},
+			queue: []testProcess{
+				{id: "i1", priority: PriorityInteractive}, {id: "i2", priority: PriorityInteractive},
+				{id: "b1", priority: PriorityBatch}, {id: "b2", priority: PriorityBatch},
+			},
+			want: []string{"i1", "b1", "i2", "b2"},
+		},
+		{
+			name:    "empty interactive class",
+			weights: config.PriorityWeights{Interactive: 3, Batch: 1},
+			queue: []testProcess{
+				{id: "b1", priority: PriorityBatch}, {id: "b2", priority: PriorityBatch}, {id: "b3", priority: PriorityBatch},
+			},
+			want: []string{"b1", "b2", "b3"},
+		},
+		{
+			name:    "empty batch class",
+			weights: config.PriorityWeights{Interactive: 3, Batch: 1},
+			queue: []testProcess{
+				{id: "i1", priority: PriorityInteractive}, {id: "i2", priority: PriorityInteractive},
+			},
+			want: []string{"i1", "i2"},
+		},
+		{
+			name:    "zero weights count as one",
+			weights: config.PriorityWeights{},
+			queue: []testProcess{
+				{id: "b1", priority: PriorityBatch}, {id: "i1", priority: PriorityInteractive},
+			},
+			want: []string{"i1", "b1"},
+		},
+		{
+			name:    "connector limit skips to the next candidate",
+			weights: config.PriorityWeights{Interactive: 3, Batch: 1},
+			active:  []testProcess{{id: "r1", connector: "limited"}},
+			queue: []testProcess{
+				{id: "i1", priority: PriorityInteractive, connector: "limited"},
+				{id: "i2", priority: PriorityInteractive},
+				{id: "b1", priority: PriorityBatch, connector: "limited"},
+			},
+			want: []string{"i2"},
+		},
+		{
+			name:    "tenant limit skips to the next candidate",
+			weights: config.PriorityWeights{Interactive: 3, Batch: 1},
+			active:  []testProcess{{id: "r1", tenant: "team"}},
+			queue: []testProcess{
+				{id: "i1", priority: PriorityInteractive, tenant: "team"},
+				{id: "b1", priority: PriorityBatch, tenant: "other"},
+				{id: "i2", priority: PriorityInteractive, tenant: "other"},
+			},
+			want: []string{"i2", "b1"},
+		},
+		{
+			name:    "global limit",
+			weights: config.PriorityWeights{Interactive: 3, Batch: 1},
+			active:  []testProcess{{id: "r1"}, {id: "r2"}},
+			queue:   []testProcess{{id: "i1", priority: PriorityInteractive}},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager(tt.weights)
+			for _, p := range tt.active {
+				m.processes[p.id] = newTestProcess(p, StatusRunning)
+			}
+			for _, p := range tt.queue {
+				process := newTestProcess(p, StatusQueued)
+				m.processes[p.id] = process
+				m.queue = append(m.queue, process)
+			}
+
+			var got []string
+			for {
+				process := m.nextQueued()
+				if process == nil {
+					break
+				}
+				m.removeQueued(process.ID)
+				got = append(got, process.ID)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("nextQueued order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 실행이 끝날 때마다 빈 슬롯에 대기열의 프로세스가 가중치와 커넥터 제한에 따라 시작되는지 확인
+func TestStartQueued(t *testing.T) {
+	m := newTestManager(config.PriorityWeights{Interactive: 2, Batch: 1})
+	m.config.Process.MaxQueue = 10
+
+	// 디스패처는 시작된 프로세스를 프롬프트로 기록 (실제로 실행하지 않으므로 pending으로 남음)
+	var started []string
+	byPrompt := make(map[string]*Process)
+	m.SetDispatcher(func(p *Process) {
+		started = append(started, p.Prompt)
+	})
+
+	create := func(prompt, priority, connector string) {
+		t.Helper()
+		if connector == "" {
+			connector = "default"
+		}
+		process, err := m.Create(Spec{Connector: connector, Prompt: prompt, Priority: priority}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		byPrompt[prompt] = process
+	}
+	finish := func(prompt string) {
+		t.Helper()
+		byPrompt[prompt].SetStatus(StatusCompleted)
+		m.StartQueued()
+	}
+
+	create("a", PriorityInteractive, "")
+	create("b", PriorityInteractive, "")
+	create("c", PriorityBatch, "")
+	create("d", PriorityInteractive, "limited")
+	create("e", PriorityInteractive, "limited")
+	create("f", PriorityInteractive, "")
+	create("g", "", "")
+
+	if !slices.Equal(started, []string{"a", "b"}) {
+		t.Fatalf("started = %v, want [a b]", started)
+	}
+	if got := m.QueueLength(); got != 5 {
+		t.Fatalf("queue length = %d, want 5", got)
+	}
+	if got := m.WaitReason(byPrompt["c"].ID); got != WaitReasonGlobalLimit {
+		t.Errorf("wait reason of c = %s, want %s", got, WaitReasonGlobalLimit)
+	}
+	if got := m.QueuePosition(byPrompt["f"].ID); got != 3 {
+		t.Errorf("queue position of f = %d, want 3 (among interactive)", got)
+	}
+
+	finish("a") // d 시작 (interactive 차례)
+	finish("b") // batch 차례이므로 c 시작
+	finish("c") // d가 limited 커넥터의 유일한 슬롯을 차지하므로 e를 건너뛰고 f 시작
+	finish("d") // e 시작
+	finish("f") // 우선순위를 생략한 g는 interactive로 시작
+
+	if want := []string{"a", "b", "d", "c", "f", "e", "g"}; !slices.Equal(started, want) {
+		t.Errorf("start order = %v, want %v", started, want)
+	}
+	if got := byPrompt["g"].Priority; got != PriorityInteractive {
+		t.Errorf("default priority = %s, want %s", got, PriorityInteractive)
+	}
+	if got := m.QueueLength(); got != 0 {
+		t.Errorf("queue length = %d, want 0", got)
+	}
+}
+
+// 슬롯이 비었지만 대기 프로세스가 아직 시작되지 않은 사이에 들어온 요청은 먼저 기다리던 프로세스를 앞지르지 않음
+func TestCreateKeepsQueueOrder(t *testing.T) {
+	m := newTestManager(config.PriorityWeights{Interactive: 1, Batch: 1})
+	m.config.Process.MaxQueue = 10
+
+	var started []string
+	m.SetDispatcher(func(p *Process) {
+		started = append(started, p.Prompt)
+	})
+
+	byPrompt := make(map[string]*Process)
+	for _, prompt := range []string{"a", "b", "c"} {
+		process, err := m.Create(Spec{Connector: "default", Prompt: prompt}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		byPrompt[prompt] = process
+	}
+
+	// a가 끝났지만 StartQueued가 호출되기 전에 d가 생성됨
+	byPrompt["a"].SetStatus(StatusCompleted)
+	d, err := m.Create(Spec{Connector: "default", Prompt: "d"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"a", "b", "c"}; !slices.Equal(started, want) {
+		t.Errorf("started = %v, want %v", started, want)
+	}
+	if got := d.CurrentStatus(); got != StatusQueued {
+		t.Errorf("status of d = %s, want %s", got, StatusQueued)
+	}
+	if got := m.QueueLength(); got != 1 {
+		t.Errorf("queue length = %d, want 1", got)
+	}
+}
+
+// 실행 슬롯 계산은 Runner가 상태를 바꾸는 동안에도 프로세스 잠금을 거쳐 상태를 읽음 (-race로 확인)
+func TestActiveCountWhileStatusChanges(t *testing.T) {
+	m := newTestManager(config.PriorityWeights{})
+	process := newTestProcess(testProcess{id: "r1", tenant: "team"}, StatusRunning)
+	m.processes[process.ID] = process
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		process.SetStatus(StatusCompleted)
+	}()
+	m.mu.RLock()
+	m.activeCount()
+	m.connectorActiveCount("default")
+	m.tenantActiveCount("team")
+	m.tenantRuntime("team", time.Now())
+	m.mu.RUnlock()
+	<-done
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if got := m.activeCount(); got != 0 {
+		t.Errorf("activeCount = %d after completion, want 0", got)
+	}
+	if got := m.connectorActiveCount("default"); got != 0 {
+		t.Errorf("connectorActiveCount = %d after completion, want 0", got)
+	}
+	if got := m.tenantActiveCount("team"); got != 0 {
+		t.Errorf("tenantActiveCount = %d after completion, want 0", got)
+	}
+}
+
+// newTestManager는 전역 동시 실행 수 2, limited 커넥터와 team 테넌트의 동시 실행 수 1인 매니저를 생성합니다
+func newTestManager(weights config.PriorityWeights) *Manager {
+	cfg := &config.Config{
+		Process: config.ProcessConfig{
+			MaxConcurrent:   2,
+			PriorityWeights: weights,
+			BufferSize:      10,
+		},
+		Connectors: config.ConnectorsConfig{
+			"limited": {MaxConcurrent: 1},
+		},
+		Tenants: config.TenantsConfig{
+			Quotas: map[string]config.TenantQuota{"team": {MaxConcurrent: 1}},
+		},
+	}
+	return NewManager(cfg, nil, nil, zerolog.Nop())
+}
+
+// newTestProcess는 지정한 상태의 프로세스를 생성합니다 (커넥터를 생략하면 default)
+func newTestProcess(p testProcess, status string) *Process {
+	connector := p.connector
+	if connector == "" {
+		connector = "default"
+	}
+	process := NewProcess(p.id, Spec{Connector: connector, Priority: p.priority, Tenant: p.tenant}, 10)
+	process.Status = status
+	return process
+}
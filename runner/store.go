@@ -0,0 +1,214 @@
+package runner
+
+import "time"
+
+// ProcessStore는 프로세스 기록을 영구 저장합니다 (이벤트는 EventLog에 저장)
+// 서버가 재시작되거나 메모리에서 정리된 뒤에도 기록을 조회할 수 있습니다
+// 구현체는 Save, Delete를 호출된 순서대로 반영해야 합니다
+type ProcessStore interface {
+	// Save는 프로세스 메타데이터와 결과를 저장합니다 (같은 ID는 덮어씀)
+	Save(record ProcessRecord) error
+	// Delete는 프로세스 기록을 삭제합니다
+	Delete(processID string) error
+	// Get은 프로세스 기록을 반환합니다 (없으면 ErrProcessNotFound)
+	Get(processID string) (*ProcessRecord, error)
+	// List는 조건에 맞는 기록을 최근 시작 순으로 반환합니다
+	List(filter ProcessFilter) ([]ProcessRecord, error)
+	// Prune은 before 이전에 종료된 기록을 삭제하고 삭제한 수를 반환합니다
+	Prune(before time.Time) (int, error)
+	Close() error
+}
+
+// StoreHealth는 백그라운드 쓰기 실패를 알릴 수 있는 저장소가 구현합니다
+type StoreHealth interface {
+	// Err는 마지막 쓰기가 실패했으면 그 에러를 반환합니다 (이후 쓰기가 성공하면 nil)
+	Err() error
+}
+
+// ProcessRecord는 저장소에 보관되는 프로세스 스냅샷입니다
+type ProcessRecord struct {
+	ID          string        `json:"id"`
+	Connector   string        `json:"connector"`
+	Prompt      string        `json:"prompt"`
+	WorkDir     string        `json:"workDir,omitempty"`
+	Options     Options       `json:"options"`
+	ParentID    string        `json:"parentId,omitempty"`
+	SessionID   string        `json:"sessionId,omitempty"`
+	Priority    string        `json:"priority"`
+	Owner       string        `json:"owner,omitempty"`
+	Tenant      string        `json:"tenant,omitempty"`
+	Timeout     time.Duration `json:"timeout"`
+	IdleTimeout time.Duration `json:"idleTimeout,omitempty"`
+	Status      string        `json:"status"`
+	StartedAt   time.Time     `json:"startedAt"`
+	RunningAt   *time.Time    `json:"runningAt,omitempty"`
+	CompletedAt *time.Time    `json:"completedAt,omitempty"`
+	Result      *Result       `json:"result,omitempty"`
+}
+
+// IsActive는 기록 시점에 프로세스가 아직 종료되지 않았는지 여부를 반환합니다
+func (r ProcessRecord) IsActive() bool {
+	return r.Status == StatusQueued || r.Status == StatusPending || r.Status == StatusRunning
+}
+
+// Summary는 API 응답에 사용하는 상태 정보를 반환합니다
+func (r ProcessRecord) Summary() map[string]interface{} {
+	status := map[string]interface{}{
+		"id":        r.ID,
+		"connector": r.Connector,
+		"prompt":    r.Prompt,
+		"status":    r.Status,
+		"priority":  r.Priority,
+		"startedAt": r.StartedAt,
+	}
+
+	if r.WorkDir != "" {
+		status["workDir"] = r.WorkDir
+	}
+
+	if !r.Options.IsZero() {
+		status["options"] = r.Options
+	}
+
+	if r.ParentID != "" {
+		status["parentId"] = r.ParentID
+	}
+
+	if r.SessionID != "" {
+		status["sessionId"] = r.SessionID
+	}
+
+	if r.Owner != "" {
+		status["owner"] = r.Owner
+	}
+
+	if r.Tenant != "" {
+		status["tenant"] = r.Tenant
+	}
+
+	if r.Timeout > 0 {
+		status["timeoutSeconds"] = int(r.Timeout.Seconds())
+	}
+
+	if r.IdleTimeout > 0 {
+		status["idleTimeoutSeconds"] = int(r.IdleTimeout.Seconds())
+	}
+
+	if r.CompletedAt != nil {
+		status["completedAt"] = r.CompletedAt
+	}
+
+	if r.Result != nil {
+		status["result"] = r.Result
+	}
+
+	return status
+}
+
+// ProcessFilter는 저장된 기록의 조회 조건입니다
+type ProcessFilter struct {
+	Connector string   // 비어있으면 모든 커넥터
+	Tenant    string   // 비어있으면 모든 테넌트
+	Statuses  []string // 비어있으면 모든 상태
+	Limit     int      // 0이면 제한 없음
+	Offset    int
+}
+
+// Matches는 기록이 조건(커넥터, 테넌트, 상태)에 맞는지 여부를 반환합니다
+func (f ProcessFilter) Matches(record ProcessRecord) bool {
+	if f.Connector != "" && record.Connector != f.Connector {
+		return false
+	}
+	if f.Tenant != "" && record.Tenant != f.Tenant {
+		return false
+	}
+	if len(f.Statuses) == 0 {
+		return true
+	}
+	for _, status := range f.Statuses {
+		if record.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Record는 현재 상태의 스냅샷을 반환합니다
+func (p *Process) Record() ProcessRecord {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return ProcessRecord{
+		ID:          p.ID,
+		Connector:   p.Connector,
+		Prompt:      p.Prompt,
+		WorkDir:     p.WorkDir,
+		Options:     p.Options,
+		ParentID:    p.ParentID,
+		SessionID:   p.SessionID,
+		Priority:    p.Priority,
+		Owner:       p.Owner,
+		Tenant:      p.Tenant,
+		Timeout:     p.Timeout,
+		IdleTimeout: p.IdleTimeout,
+		Status:      p.Status,
+		StartedAt:   p.StartedAt,
+		RunningAt:   p.RunningAt,
+		CompletedAt: p.CompletedAt,
+		Result:      p.result,
+	}
+}
+
+// persist는 저장소가 설정된 경우 현재 스냅샷을 저장합니다
+// 저장 실패는 저장소 구현에서 로깅하므로 실행 흐름에는 영향을 주지 않습니다
+func (p *Process) persist() {
+	if p.store == nil {
+		return
+	}
+	_ = p.store.Save(p.Record())
+}
+
+// restoreProcess는 저장된 기록으로부터 종료된 읽기 전용 프로세스를 복원합니다
+// 이벤트 로그의 최근 이벤트를 버퍼 크기만큼 캐시하고, 그 이전 이벤트는 필요할 때 로그에서 읽습니다
+func restoreProcess(record ProcessRecord, eventLog EventLog, bufferSize int) (*Process, error) {
+	process := NewProcess(record.ID, Spec{
+		Connector:   record.Connector,
+		Prompt:      record.Prompt,
+		WorkDir:     record.WorkDir,
+		Options:     record.Options,
+		ParentID:    record.ParentID,
+		Priority:    record.Priority,
+		Owner:       record.Owner,
+		Tenant:      record.Tenant,
+		Timeout:     record.Timeout,
+		IdleTimeout: record.IdleTimeout,
+	}, bufferSize)
+
+	process.SessionID = record.SessionID
+	process.Status = record.Status
+	process.StartedAt = record.StartedAt
+	process.RunningAt = record.RunningAt
+	process.CompletedAt = record.CompletedAt
+	process.result = record.Result
+
+	if eventLog != nil {
+		count, err := eventLog.Len(record.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		events, err := eventLog.Read(record.ID, max(count-bufferSize, 0), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			process.events.Push(event)
+		}
+		process.eventCount = count
+		process.eventLog = eventLog
+	}
+	close(process.done)
+
+	return process, nil
+}
@@ -0,0 +1,240 @@
+package runner
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"cli-runner/pkg/metrics"
+)
+
+// 구독자 채널이 가득 찼을 때의 처리 방식
+const (
+	BackpressureBlock      = "block"      // blockTimeout 동안 기다린 뒤에도 가득 차 있으면 drop과 같이 처리
+	BackpressureDrop       = "drop"       // 이벤트를 버리고 다음 전달 전에 gap 이벤트로 누락 범위를 알림
+	BackpressureDisconnect = "disconnect" // 구독을 끊음 (클라이언트는 마지막 순번부터 다시 구독)
+)
+
+// BackpressurePolicies는 지원하는 모든 처리 방식입니다
+var BackpressurePolicies = []string{BackpressureBlock, BackpressureDrop, BackpressureDisconnect}
+
+// EventTypeGap은 느린 구독자에게 누락된 이벤트 범위를 알리는 이벤트 타입입니다
+// 이벤트 로그에는 기록되지 않으며 순번(Seq)이 0입니다
+const EventTypeGap = "gap"
+
+// Gap은 gap 이벤트의 데이터로, 전달되지 못한 이벤트의 순번 범위입니다
+type Gap struct {
+	From   int `json:"from" example:"120"` // 누락된 첫 순번
+	To     int `json:"to" example:"180"`   // 누락된 마지막 순번
+	Missed int `json:"missed" example:"61"`
+}
+
+// SubscribeOptions는 구독자별 전달 방식입니다
+type SubscribeOptions struct {
+	Backpressure string        // block, drop, disconnect (비어있으면 drop)
+	BlockTimeout time.Duration // block일 때 이벤트 하나당 최대 대기 시간
+	BufferSize   int           // 구독자 채널 크기
+}
+
+// Subscription은 구독 시점 이후의 실시간 이벤트 채널입니다
+// 구독 시점까지의 이벤트(offset < Next)는 EventsFrom으로 읽으면 누락이나 중복 없이 이어집니다
+type Subscription struct {
+	Events <-chan Event // 프로세스가 종료되거나 구독이 끊기면 닫힘
+	Next   int          // 구독 시점의 이벤트 수 (채널로 전달될 첫 이벤트의 offset)
+
+	process *Process
+	id      string
+	sub     *subscriber
+}
+
+// Close는 구독을 해제합니다
+func (s *Subscription) Close() {
+	s.process.Unsubscribe(s.id)
+}
+
+// Disconnected는 느린 구독자라서 disconnect 정책에 의해 구독이 끊겼는지 여부를 반환합니다
+func (s *Subscription) Disconnected() bool {
+	s.sub.mu.Lock()
+	defer s.sub.mu.Unlock()
+	return s.sub.disconnected
+}
+
+// subscriber는 구독자 채널과 누락 상태입니다
+// 전달은 Process.mu 밖에서 이루어지므로 채널 전송과 닫기, 누락 상태는 구독자의 잠금으로 보호합니다
+type subscriber struct {
+	ch           chan Event
+	options      SubscribeOptions
+	gapFrom      int // 아직 알리지 못한 누락 범위 (0이면 누락 없음)
+	gapTo        int
+	disconnected bool // disconnect 정책으로 끊김
+	closed       bool // 채널이 닫힘
+	mu           sync.Mutex
+}
+
+// Subscribe는 현재까지의 이벤트 수를 기록하고 이후 이벤트를 받을 채널을 등록합니다
+// 두 작업을 같은 잠금 안에서 수행하므로 재생과 실시간 전달 사이에 이벤트가 누락되지 않습니다
+// 이미 종료된 프로세스는 닫힌 채널을 반환합니다
+func (p *Process) Subscribe(subscriberID string, options SubscribeOptions) *Subscription {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if options.Backpressure == "" {
+		options.Backpressure = BackpressureDrop
+	}
+	if options.BufferSize <= 0 {
+		options.BufferSize = 100
+	}
+
+	sub := &subscriber{
+		ch:      make(chan Event, options.BufferSize),
+		options: options,
+	}
+
+	select {
+	case <-p.done:
+		// 종료된 프로세스에는 더 이상 이벤트가 추가되지 않음
+		sub.close()
+	default:
+		p.subscribers[subscriberID] = sub
+	}
+
+	return &Subscription{
+		Events:  sub.ch,
+		Next:    p.eventCount,
+		process: p,
+		id:      subscriberID,
+		sub:     sub,
+	}
+}
+
+// Unsubscribe는 구독자를 제거합니다
+// 전달 중인 이벤트가 있으면 (block 정책의 대기 포함) 전달이 끝난 뒤 채널을 닫습니다
+func (p *Process) Unsubscribe(subscriberID string) {
+	p.mu.Lock()
+	sub, exists := p.subscribers[subscriberID]
+	delete(p.subscribers, subscriberID)
+	p.mu.Unlock()
+
+	if exists {
+		sub.close()
+	}
+}
+
+// deliver는 구독자의 정책에 따라 이벤트를 전달합니다 (p.mu를 보유하지 않은 상태에서 호출)
+// block 정책으로 기다리는 동안에도 프로세스 조회와 다른 이벤트의 기록을 막지 않습니다
+func (p *Process) deliver(subscriberID string, sub *subscriber, event Event) {
+	sub.mu.Lock()
+	if sub.closed {
+		// 전달 대상을 정한 뒤 구독이 해제되었거나 프로세스가 종료됨
+		sub.mu.Unlock()
+		return
+	}
+	if !sub.deliver(event) {
+		p.overflow(sub, event)
+	}
+	disconnected := sub.disconnected
+	sub.mu.Unlock()
+
+	// 끊긴 구독자는 이후 이벤트의 전달 대상에서 제외
+	if disconnected {
+		p.mu.Lock()
+		if p.subscribers[subscriberID] == sub {
+			delete(p.subscribers, subscriberID)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// deliver는 이전에 누락된 범위가 있으면 먼저 알린 뒤 이벤트를 보냅니다 (s.mu를 보유한 상태에서 호출)
+func (s *subscriber) deliver(event Event) bool {
+	if s.gapFrom > 0 {
+		if !s.send(GapEvent(s.gapFrom, s.gapTo)) {
+			return false
+		}
+		s.gapFrom, s.gapTo = 0, 0
+	}
+	return s.send(event)
+}
+
+// overflow는 전달하지 못한 이벤트를 정책에 따라 처리합니다 (sub.mu를 보유한 상태에서 호출)
+func (p *Process) overflow(sub *subscriber, event Event) {
+	metrics.DroppedEvents.WithLabelValues(p.Connector, sub.options.Backpressure).Inc()
+
+	if sub.options.Backpressure == BackpressureDisconnect {
+		sub.disconnected = true
+		sub.closeLocked()
+		return
+	}
+
+	// drop (block은 대기 후에도 가득 차면 drop과 같음)
+	if sub.gapFrom == 0 {
+		sub.gapFrom = event.Seq
+	}
+	sub.gapTo = event.Seq
+}
+
+// send는 채널에 이벤트를 넣습니다
+// block 정책이면 blockTimeout까지 기다리고, 그 외에는 즉시 실패합니다
+func (s *subscriber) send(event Event) bool {
+	select {
+	case s.ch <- event:
+		return true
+	default:
+	}
+
+	if s.options.Backpressure != BackpressureBlock || s.options.BlockTimeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(s.options.BlockTimeout)
+	defer timer.Stop()
+
+	select {
+	case s.ch <- event:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// close는 채널을 닫습니다 (이미 닫혔으면 무시)
+func (s *subscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeLocked()
+}
+
+// finish는 알리지 못한 누락 범위가 있으면 가능한 경우 먼저 알리고 채널을 닫습니다
+func (s *subscriber) finish() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	if s.gapFrom > 0 {
+		select {
+		case s.ch <- GapEvent(s.gapFrom, s.gapTo):
+		default:
+		}
+	}
+	s.closeLocked()
+}
+
+// closeLocked는 채널을 닫습니다 (s.mu를 보유한 상태에서 호출)
+func (s *subscriber) closeLocked() {
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+// GapEvent는 from부터 to까지의 순번이 전달되지 못했음을 알리는 이벤트를 생성합니다
+func GapEvent(from, to int) Event {
+	data, _ := json.Marshal(Gap{From: from, To: to, Missed: to - from + 1})
+	return Event{
+		Type:      EventTypeGap,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+}
@@ -0,0 +1,241 @@
+package runner
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+// addEvents는 n개의 stream 이벤트를 추가합니다
+func addEvents(p *Process, n int) {
+	for range n {
+		p.AddEvent(Event{Type: "stream", Data: json.RawMessage(`{}`), Timestamp: time.Now()})
+	}
+}
+
+// receive는 구독 채널에서 이벤트 하나를 읽습니다 (채널이 닫혔으면 ok가 false)
+func receive(t *testing.T, sub *Subscription) (Event, bool) {
+	t.Helper()
+	select {
+	case event, ok := <-sub.Events:
+		return event, ok
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+		return Event{}, false
+	}
+}
+
+// receiveSeq는 다음 이벤트가 seq 순번의 이벤트인지 확인합니다
+func receiveSeq(t *testing.T, sub *Subscription, seq int) {
+	t.Helper()
+	event, ok := receive(t, sub)
+	if !ok {
+		t.Fatalf("channel closed, want event %d", seq)
+	}
+	if event.Seq != seq {
+		t.Fatalf("received %s event with seq %d, want seq %d", event.Type, event.Seq, seq)
+	}
+}
+
+// receiveGap은 다음 이벤트가 from부터 to까지의 누락을 알리는 gap 이벤트인지 확인합니다
+func receiveGap(t *testing.T, sub *Subscription, from, to int) {
+	t.Helper()
+	event, ok := receive(t, sub)
+	if !ok {
+		t.Fatalf("channel closed, want gap %d-%d", from, to)
+	}
+	var gap Gap
+	if event.Type != EventTypeGap || json.Unmarshal(event.Data, &gap) != nil {
+		t.Fatalf("received %s event %s, want gap %d-%d", event.Type, event.Data, from, to)
+	}
+	if want := (Gap{From: from, To: to, Missed: to - from + 1}); gap != want {
+		t.Fatalf("gap = %+v, want %+v", gap, want)
+	}
+}
+
+// receiveClosed는 구독 채널이 닫혔는지 확인합니다
+func receiveClosed(t *testing.T, sub *Subscription) {
+	t.Helper()
+	if event, ok := receive(t, sub); ok {
+		t.Fatalf("received %s event with seq %d, want closed channel", event.Type, event.Seq)
+	}
+}
+
+func TestSubscribeContinuesReplay(t *testing.T) {
+	p := NewProcess("p1", Spec{Connector: "test"}, 10)
+	addEvents(p, 3)
+
+	sub := p.Subscribe("s1", SubscribeOptions{})
+	defer sub.Close()
+	if sub.Next != 3 {
+		t.Fatalf("Next = %d, want 3", sub.Next)
+	}
+
+	// 구독 시점까지는 재생으로, 이후는 채널로 받음
+	replayed, next, err := p.EventsFrom(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seqs []int
+	for _, event := range replayed {
+		seqs = append(seqs, event.Seq)
+	}
+	if !slices.Equal(seqs, []int{1, 2, 3}) || next != sub.Next {
+		t.Fatalf("replayed %v up to %d, want [1 2 3] up to %d", seqs, next, sub.Next)
+	}
+
+	addEvents(p, 2)
+	receiveSeq(t, sub, 4)
+	receiveSeq(t, sub, 5)
+
+	p.Close()
+	receiveClosed(t, sub)
+}
+
+func TestBackpressureDrop(t *testing.T) {
+	p := NewProcess("p1", Spec{Connector: "test"}, 10)
+	sub := p.Subscribe("s1", SubscribeOptions{Backpressure: BackpressureDrop, BufferSize: 2})
+	defer sub.Close()
+
+	// 버퍼 두 칸을 넘는 3, 4는 버려짐
+	addEvents(p, 4)
+	receiveSeq(t, sub, 1)
+	receiveSeq(t, sub, 2)
+
+	// 다음 이벤트 전에 누락 범위를 먼저 알림
+	addEvents(p, 1)
+	receiveGap(t, sub, 3, 4)
+	receiveSeq(t, sub, 5)
+
+	if sub.Disconnected() {
+		t.Error("drop policy disconnected the subscriber")
+	}
+}
+
+func TestBackpressureDisconnect(t *testing.T) {
+	p := NewProcess("p1", Spec{Connector: "test"}, 10)
+	sub := p.Subscribe("s1", SubscribeOptions{Backpressure: BackpressureDisconnect, BufferSize: 1})
+	defer sub.Close()
+
+	addEvents(p, 2)
+	receiveSeq(t, sub, 1)
+	receiveClosed(t, sub)
+
+	if !sub.Disconnected() {
+		t.Error("Disconnected() = false after overflow")
+	}
+
+	// 끊긴 구독자는 전달 대상에서 제외되고, 이후 이벤트는 재생으로 이어받을 수 있음
+	addEvents(p, 1)
+	p.mu.RLock()
+	subscribers := len(p.subscribers)
+	p.mu.RUnlock()
+	if subscribers != 0 {
+		t.Errorf("%d subscribers left after disconnect", subscribers)
+	}
+	if count := p.EventCount(); count != 3 {
+		t.Errorf("EventCount = %d, want 3", count)
+	}
+}
+
+func TestBackpressureBlock(t *testing.T) {
+	p := NewProcess("p1", Spec{Connector: "test"}, 10)
+	sub := p.Subscribe("s1", SubscribeOptions{
+		Backpressure: BackpressureBlock,
+		BlockTimeout: 5 * time.Second,
+		BufferSize:   1,
+	})
+	defer sub.Close()
+
+	// 느린 구독자도 blockTimeout 안에 읽으면 누락 없이 모두 받음
+	received := make(chan []int)
+	go func() {
+		var seqs []int
+		for event := range sub.Events {
+			seqs = append(seqs, event.Seq)
+			time.Sleep(10 * time.Millisecond)
+		}
+		received <- seqs
+	}()
+
+	addEvents(p, 5)
+	p.Close()
+
+	if seqs := <-received; !slices.Equal(seqs, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("received %v, want [1 2 3 4 5]", seqs)
+	}
+}
+
+func TestBackpressureBlockTimeout(t *testing.T) {
+	p := NewProcess("p1", Spec{Connector: "test"}, 10)
+	sub := p.Subscribe("s1", SubscribeOptions{
+		Backpressure: BackpressureBlock,
+		BlockTimeout: 20 * time.Millisecond,
+		BufferSize:   1,
+	})
+	defer sub.Close()
+
+	// 읽지 않는 동안 2는 blockTimeout 뒤에 버려짐
+	addEvents(p, 2)
+	receiveSeq(t, sub, 1)
+
+	// 종료 시 알리지 못한 누락 범위를 알리고 채널을 닫음
+	p.Close()
+	receiveGap(t, sub, 2, 2)
+	receiveClosed(t, sub)
+}
+
+func TestSubscribeAfterClose(t *testing.T) {
+	p := NewProcess("p1", Spec{Connector: "test"}, 10)
+	addEvents(p, 2)
+	p.Close()
+
+	sub := p.Subscribe("s1", SubscribeOptions{})
+	if sub.Next != 2 {
+		t.Errorf("Next = %d, want 2", sub.Next)
+	}
+	receiveClosed(t, sub)
+}
+
+func TestBlockedSubscriberDoesNotHoldLock(t *testing.T) {
+	p := NewProcess("p1", Spec{Connector: "test"}, 10)
+	sub := p.Subscribe("s1", SubscribeOptions{
+		Backpressure: BackpressureBlock,
+		BlockTimeout: 5 * time.Second,
+		BufferSize:   1,
+	})
+	defer sub.Close()
+
+	// 버퍼가 가득 찬 상태에서 다음 이벤트의 전달은 구독자가 읽을 때까지 기다림
+	addEvents(p, 1)
+	added := make(chan struct{})
+	go func() {
+		addEvents(p, 1)
+		close(added)
+	}()
+
+	// 기다리는 동안에도 조회와 구독은 막히지 않음
+	deadline := time.Now().Add(2 * time.Second)
+	for p.EventCount() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("second event was not recorded")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	checked := make(chan struct{})
+	go func() {
+		p.GetStatus()
+		p.Subscribe("s2", SubscribeOptions{}).Close()
+		close(checked)
+	}()
+	select {
+	case <-checked:
+	case <-time.After(time.Second):
+		t.Fatal("process lock is held while a subscriber blocks")
+	}
+
+	receiveSeq(t, sub, 1)
+	receiveSeq(t, sub, 2)
+	<-added
+}
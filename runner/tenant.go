@@ -0,0 +1,180 @@
+package runner
+
+import (
+	"fmt"
+	"time"
+
+	"cli-runner/config"
+)
+
+// 테넌트 사용량 집계 (todayUsage, usageOf, knownTenant, tenantActiveCount, checkQuota는 호출자가 Manager.mu를 보유해야 합니다)
+
+// dayLayout은 일일 사용량의 집계 기준 날짜 형식입니다 (서버 로컬 시간)
+const dayLayout = "2006-01-02"
+
+// tenantUsage는 테넌트의 하루 사용량입니다
+type tenantUsage struct {
+	day     string        // 집계 날짜
+	runs    int           // 생성된 프로세스 수
+	runtime time.Duration // 종료된 프로세스의 실행 시간 합계 (종료된 날짜 기준)
+}
+
+// TenantUsage는 테넌트의 현재 사용량과 적용 중인 제한입니다
+type TenantUsage struct {
+	Tenant  string
+	Day     string        // 집계 날짜 (서버 로컬 시간)
+	Active  int           // 실행 슬롯을 차지한 (pending, running) 프로세스 수
+	Queued  int           // 대기 중인 프로세스 수
+	Runs    int           // 오늘 생성된 프로세스 수
+	Runtime time.Duration // 오늘의 실행 시간 합계 (실행 중인 프로세스 포함)
+	Quota   config.TenantQuota
+}
+
+// TenantUsage는 테넌트의 오늘 사용량을 반환합니다
+// API 키, 사용량 제한 설정, 사용 기록이 모두 없는 테넌트이면 false를 반환합니다
+func (m *Manager) TenantUsage(tenant string) (TenantUsage, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.knownTenant(tenant) {
+		return TenantUsage{}, false
+	}
+
+	now := time.Now()
+	usage := m.todayUsage(tenant, now)
+
+	queued := 0
+	for _, p := range m.queue {
+		if p.Tenant == tenant {
+			queued++
+		}
+	}
+
+	return TenantUsage{
+		Tenant:  tenant,
+		Day:     usage.day,
+		Active:  m.tenantActiveCount(tenant),
+		Queued:  queued,
+		Runs:    usage.runs,
+		Runtime: m.tenantRuntime(tenant, now),
+		Quota:   m.config.Tenants.Quota(tenant),
+	}, true
+}
+
+// knownTenant는 API 키, 사용량 제한 설정 또는 사용 기록이 있는 테넌트인지 확인합니다
+func (m *Manager) knownTenant(tenant string) bool {
+	if tenant == "" {
+		return false
+	}
+	for _, key := range m.config.Auth.Keys {
+		if key.TenantName() == tenant {
+			return true
+		}
+	}
+	if _, ok := m.config.Tenants.Quotas[tenant]; ok {
+		return true
+	}
+	if _, ok := m.usage[tenant]; ok {
+		return true
+	}
+	for _, p := range m.processes {
+		if p.Tenant == tenant {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreUsage는 저장소의 오늘 기록으로 테넌트 사용량을 다시 집계합니다
+// 재시작 직후에는 실행 중인 프로세스가 없으므로 모든 실행 시간이 종료된 기록에 포함됩니다
+func (m *Manager) restoreUsage() error {
+	records, err := m.store.List(ProcessFilter{})
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	today := now.Format(dayLayout)
+	for _, record := range records {
+		if record.Tenant == "" {
+			continue
+		}
+
+		usage := m.usageOf(record.Tenant, now)
+		if record.StartedAt.Format(dayLayout) == today {
+			usage.runs++
+		}
+		if record.RunningAt != nil && record.CompletedAt != nil && record.CompletedAt.Format(dayLayout) == today {
+			usage.runtime += record.CompletedAt.Sub(*record.RunningAt)
+		}
+	}
+
+	return nil
+}
+
+// todayUsage는 테넌트의 오늘 사용량을 반환합니다 (기록이 없으면 0이며 상태를 만들지 않음)
+func (m *Manager) todayUsage(tenant string, now time.Time) tenantUsage {
+	day := now.Format(dayLayout)
+	if usage, exists := m.usage[tenant]; exists && usage.day == day {
+		return *usage
+	}
+	return tenantUsage{day: day}
+}
+
+// usageOf는 기록할 테넌트의 오늘 사용량을 반환합니다 (날짜가 바뀌었으면 초기화)
+func (m *Manager) usageOf(tenant string, now time.Time) *tenantUsage {
+	day := now.Format(dayLayout)
+
+	usage, exists := m.usage[tenant]
+	if !exists || usage.day != day {
+		usage = &tenantUsage{day: day}
+		m.usage[tenant] = usage
+	}
+	return usage
+}
+
+// tenantActiveCount는 실행 슬롯을 차지한 해당 테넌트의 프로세스 수를 반환합니다
+func (m *Manager) tenantActiveCount(tenant string) int {
+	count := 0
+	for _, p := range m.processes {
+		if p.Tenant == tenant && p.holdsSlot() {
+			count++
+		}
+	}
+	return count
+}
+
+// tenantRuntime은 종료된 프로세스와 실행 중인 프로세스의 오늘 실행 시간 합계를 반환합니다
+func (m *Manager) tenantRuntime(tenant string, now time.Time) time.Duration {
+	runtime := m.todayUsage(tenant, now).runtime
+	for _, p := range m.processes {
+		if p.Tenant == tenant && p.CurrentStatus() == StatusRunning {
+			runtime += p.Runtime(now)
+		}
+	}
+	return runtime
+}
+
+// checkQuota는 테넌트가 오늘 새 프로세스를 더 실행할 수 있는지 확인합니다
+// 실행 시간 제한은 새 프로세스만 막으며, 이미 실행 중인 프로세스는 중지하지 않습니다
+func (m *Manager) checkQuota(tenant string, now time.Time) error {
+	if tenant == "" {
+		return nil
+	}
+
+	quota := m.config.Tenants.Quota(tenant)
+	if quota.MaxDailyRuns > 0 {
+		if runs := m.todayUsage(tenant, now).runs; runs >= quota.MaxDailyRuns {
+			return fmt.Errorf("%w: daily run limit of %d reached", ErrTenantQuota, quota.MaxDailyRuns)
+		}
+	}
+	if quota.MaxDailyRuntime > 0 {
+		if runtime := m.tenantRuntime(tenant, now); runtime >= quota.MaxDailyRuntime {
+			return fmt.Errorf("%w: daily runtime limit of %s reached", ErrTenantQuota, quota.MaxDailyRuntime)
+		}
+	}
+	return nil
+}
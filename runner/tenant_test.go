@@ -0,0 +1,180 @@
+package runner
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cli-runner/config"
+)
+
+// newTenantManager는 tenants 설정을 적용한 테스트 매니저를 생성합니다
+func newTenantManager(tenants config.TenantsConfig) *Manager {
+	m := newTestManager(config.PriorityWeights{Interactive: 1, Batch: 1})
+	m.config.Process.MaxConcurrent = 10
+	m.config.Process.MaxQueue = 10
+	m.config.Tenants = tenants
+	return m
+}
+
+func TestTenantQuota(t *testing.T) {
+	tests := []struct {
+		name    string
+		tenants config.TenantsConfig
+		tenant  string
+		runtime time.Duration // 오늘 이미 종료된 프로세스의 실행 시간
+		runs    int           // 거부 없이 생성되어야 하는 프로세스 수
+	}{
+		{
+			name:    "daily run limit",
+			tenants: config.TenantsConfig{Quotas: map[string]config.TenantQuota{"team": {MaxDailyRuns: 2}}},
+			tenant:  "team",
+			runs:    2,
+		},
+		{
+			name:    "default quota for tenants without their own",
+			tenants: config.TenantsConfig{Default: config.TenantQuota{MaxDailyRuns: 1}},
+			tenant:  "other",
+			runs:    1,
+		},
+		{
+			name:    "daily runtime limit",
+			tenants: config.TenantsConfig{Quotas: map[string]config.TenantQuota{"team": {MaxDailyRuntime: time.Minute}}},
+			tenant:  "team",
+			runtime: time.Minute,
+			runs:    0,
+		},
+		{
+			name:    "runtime below the limit",
+			tenants: config.TenantsConfig{Quotas: map[string]config.TenantQuota{"team": {MaxDailyRuntime: time.Minute, MaxDailyRuns: 3}}},
+			tenant:  "team",
+			runtime: 59 * time.Second,
+			runs:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTenantManager(tt.tenants)
+			m.usageOf(tt.tenant, time.Now()).runtime = tt.runtime
+
+			for i := range tt.runs {
+				if _, err := m.Create(Spec{Connector: "default", Tenant: tt.tenant}, nil); err != nil {
+					t.Fatalf("run %d: %v", i+1, err)
+				}
+			}
+			_, err := m.Create(Spec{Connector: "default", Tenant: tt.tenant}, nil)
+			if !errors.Is(err, ErrTenantQuota) {
+				t.Fatalf("run %d: error = %v, want %v", tt.runs+1, err, ErrTenantQuota)
+			}
+
+			// 거부된 요청은 실행 수에 포함되지 않음
+			if got := m.todayUsage(tt.tenant, time.Now()).runs; got != tt.runs {
+				t.Errorf("runs = %d, want %d", got, tt.runs)
+			}
+		})
+	}
+}
+
+func TestTenantQuotaIgnoresRequestsWithoutTenant(t *testing.T) {
+	m := newTenantManager(config.TenantsConfig{Default: config.TenantQuota{MaxDailyRuns: 1}})
+
+	for i := range 3 {
+		if _, err := m.Create(Spec{Connector: "default"}, nil); err != nil {
+			t.Fatalf("run %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestTenantUsageResetsDaily(t *testing.T) {
+	m := newTenantManager(config.TenantsConfig{Default: config.TenantQuota{MaxDailyRuns: 1}})
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	usage := m.usageOf("team", yesterday)
+	usage.runs = 5
+	usage.runtime = time.Hour
+
+	if _, err := m.Create(Spec{Connector: "default", Tenant: "team"}, nil); err != nil {
+		t.Fatalf("yesterday's usage blocked a run: %v", err)
+	}
+	if got := m.usageOf("team", time.Now()); got.runs != 1 || got.runtime != 0 {
+		t.Errorf("today's usage = %d runs, %s, want 1 run, 0s", got.runs, got.runtime)
+	}
+}
+
+// 테넌트의 동시 실행 제한에 걸린 프로세스는 대기하고, 그동안 다른 테넌트의 프로세스가 먼저 시작됨
+func TestTenantConcurrencyLimit(t *testing.T) {
+	m := newTenantManager(config.TenantsConfig{
+		Quotas: map[string]config.TenantQuota{"team": {MaxConcurrent: 1}},
+	})
+
+	var started []*Process
+	m.SetDispatcher(func(p *Process) {
+		started = append(started, p)
+	})
+
+	create := func(tenant string) *Process {
+		t.Helper()
+		process, err := m.Create(Spec{Connector: "default", Tenant: tenant}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return process
+	}
+
+	first := create("team")
+	waiting := create("team")
+	other := create("ops")
+
+	if len(started) != 2 || started[0] != first || started[1] != other {
+		t.Fatalf("started %d processes, want the first team process and the ops process", len(started))
+	}
+	if got := m.WaitReason(waiting.ID); got != WaitReasonTenantLimit {
+		t.Errorf("wait reason = %q, want %q", got, WaitReasonTenantLimit)
+	}
+
+	first.SetStatus(StatusCompleted)
+	m.StartQueued()
+	if len(started) != 3 || started[2] != waiting {
+		t.Errorf("waiting team process was not started after the first one finished")
+	}
+}
+
+func TestTenantUsage(t *testing.T) {
+	m := newTenantManager(config.TenantsConfig{
+		Quotas: map[string]config.TenantQuota{"configured": {MaxDailyRuns: 5}},
+	})
+	m.config.Auth.Keys = []config.APIKeyConfig{{Name: "alice", Tenant: "Team"}}
+
+	if _, err := m.Create(Spec{Connector: "default", Tenant: "used"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tenant string
+		known  bool
+	}{
+		{tenant: "team", known: true},       // API 키의 테넌트
+		{tenant: "configured", known: true}, // 사용량 제한 설정
+		{tenant: "used", known: true},       // 사용 기록
+		{tenant: "unknown", known: false},
+		{tenant: "", known: false},
+	}
+
+	for _, tt := range tests {
+		usage, ok := m.TenantUsage(tt.tenant)
+		if ok != tt.known {
+			t.Errorf("TenantUsage(%q) known = %v, want %v", tt.tenant, ok, tt.known)
+		}
+		if ok && usage.Tenant != tt.tenant {
+			t.Errorf("TenantUsage(%q).Tenant = %q", tt.tenant, usage.Tenant)
+		}
+	}
+
+	if usage, _ := m.TenantUsage("configured"); usage.Quota.MaxDailyRuns != 5 {
+		t.Errorf("quota = %+v, want maxDailyRuns 5", usage.Quota)
+	}
+	if usage, _ := m.TenantUsage("used"); usage.Runs != 1 || usage.Active != 1 {
+		t.Errorf("usage = %d runs, %d active, want 1 and 1", usage.Runs, usage.Active)
+	}
+}
@@ -0,0 +1,63 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// stopCause는 프로세스 context가 취소된 원인입니다
+// context.Cause로 조회하여 Result.Reason과 에러 메시지를 결정합니다
+type stopCause struct {
+	reason  string
+	message string
+}
+
+func (c *stopCause) Error() string {
+	return c.message
+}
+
+// newStopCause는 종료 사유와 메시지로 취소 원인을 생성합니다
+func newStopCause(reason, message string) error {
+	return &stopCause{reason: reason, message: message}
+}
+
+// causeOf는 context가 취소된 사유와 메시지를 반환합니다
+func causeOf(ctx context.Context) (reason, message string) {
+	return stopReason(context.Cause(ctx))
+}
+
+// stopReason은 취소 원인에서 사유와 메시지를 추출합니다
+func stopReason(err error) (reason, message string) {
+	var cause *stopCause
+	if errors.As(err, &cause) {
+		return cause.reason, cause.message
+	}
+	return ReasonUserStop, "Process stopped"
+}
+
+// watchIdle은 idleTimeout 동안 새 이벤트가 없으면 프로세스를 취소합니다
+// ctx가 끝나면 반환합니다
+func watchIdle(ctx context.Context, process *Process, idleTimeout time.Duration, cancel context.CancelCauseFunc) {
+	last := time.Now()
+	timer := time.NewTimer(idleTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			if t := process.LastEventAt(); t.After(last) {
+				last = t
+			}
+
+			idle := time.Since(last)
+			if idle >= idleTimeout {
+				cancel(newStopCause(ReasonIdleTimeout, "Process produced no output for "+idleTimeout.String()))
+				return
+			}
+			timer.Reset(idleTimeout - idle)
+		}
+	}
+}
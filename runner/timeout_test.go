@@ -0,0 +1,203 @@
+package runner
+
+import (
+	"encoding/json"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"cli-runner/config"
+)
+
+// shellConnector는 프롬프트 대신 script를 sh로 실행하고 출력 라인을 stream 이벤트로 전달하는 테스트용 커넥터입니다
+type shellConnector struct {
+	script string
+}
+
+func (c shellConnector) Name() string {
+	return "shell"
+}
+
+func (c shellConnector) BuildCommand(string, Options) *exec.Cmd {
+	return exec.Command("sh", "-c", c.script)
+}
+
+func (c shellConnector) ParseLine(line string) (*Event, error) {
+	data, err := json.Marshal(map[string]string{"text": line})
+	if err != nil {
+		return nil, err
+	}
+	return &Event{Type: "stream", Data: data}, nil
+}
+
+// runScript는 script를 실행하는 프로세스를 생성하고 종료될 때까지 기다립니다
+func runScript(t *testing.T, cfg *config.Config, spec Spec, script string) *Process {
+	t.Helper()
+
+	manager := NewManager(cfg, nil, nil, zerolog.Nop())
+	NewRunner(manager, zerolog.Nop())
+
+	spec.Connector = "shell"
+	process, err := manager.Create(spec, shellConnector{script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitDone(t, process)
+	return process
+}
+
+// waitDone은 프로세스의 마지막 이벤트가 done이 될 때까지 기다립니다
+func waitDone(t *testing.T, process *Process) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if events := process.GetEvents(); len(events) > 0 && events[len(events)-1].Type == "done" {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("process did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestProcessTimeouts(t *testing.T) {
+	tests := []struct {
+		name             string
+		script           string
+		spec             Spec
+		connectorTimeout time.Duration
+		maxTimeout       time.Duration
+		wantStatus       string
+		wantReason       string
+		wantTimeout      time.Duration
+	}{
+		{
+			name:        "finishes within timeout",
+			script:      "echo done",
+			spec:        Spec{Timeout: 5 * time.Second},
+			wantStatus:  StatusCompleted,
+			wantTimeout: 5 * time.Second,
+		},
+		{
+			name:        "request timeout",
+			script:      "exec sleep 5",
+			spec:        Spec{Timeout: 200 * time.Millisecond},
+			wantStatus:  StatusStopped,
+			wantReason:  ReasonTimeout,
+			wantTimeout: 200 * time.Millisecond,
+		},
+		{
+			name:             "connector timeout when the request has none",
+			script:           "exec sleep 5",
+			connectorTimeout: 200 * time.Millisecond,
+			wantStatus:       StatusStopped,
+			wantReason:       ReasonTimeout,
+			wantTimeout:      200 * time.Millisecond,
+		},
+		{
+			name:        "maxTimeout caps the request",
+			script:      "exec sleep 5",
+			spec:        Spec{Timeout: time.Hour},
+			maxTimeout:  200 * time.Millisecond,
+			wantStatus:  StatusStopped,
+			wantReason:  ReasonTimeout,
+			wantTimeout: 200 * time.Millisecond,
+		},
+		{
+			name:        "idle timeout",
+			script:      "echo started; exec sleep 5",
+			spec:        Spec{Timeout: 10 * time.Second, IdleTimeout: 300 * time.Millisecond},
+			wantStatus:  StatusStopped,
+			wantReason:  ReasonIdleTimeout,
+			wantTimeout: 10 * time.Second,
+		},
+		{
+			name:        "output resets the idle timer",
+			script:      "for i in 1 2 3 4 5 6; do echo $i; sleep 0.1; done",
+			spec:        Spec{Timeout: 10 * time.Second, IdleTimeout: 400 * time.Millisecond},
+			wantStatus:  StatusCompleted,
+			wantTimeout: 10 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Process: config.ProcessConfig{
+					DefaultTimeout: time.Minute,
+					MaxTimeout:     tt.maxTimeout,
+					MaxConcurrent:  1,
+					BufferSize:     100,
+				},
+				Connectors: config.ConnectorsConfig{
+					"shell": {Timeout: tt.connectorTimeout},
+				},
+			}
+
+			process := runScript(t, cfg, tt.spec, tt.script)
+
+			if status := process.GetStatus()["status"]; status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", status, tt.wantStatus)
+			}
+			if process.Timeout != tt.wantTimeout {
+				t.Errorf("timeout = %s, want %s", process.Timeout, tt.wantTimeout)
+			}
+			result := process.GetResult()
+			if result == nil {
+				t.Fatal("result is not set")
+			}
+			if result.Reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q (error: %s)", result.Reason, tt.wantReason, result.Error)
+			}
+		})
+	}
+}
+
+func TestStopReason(t *testing.T) {
+	cfg := &config.Config{
+		Process: config.ProcessConfig{DefaultTimeout: time.Minute, MaxConcurrent: 1, BufferSize: 100},
+	}
+	manager := NewManager(cfg, nil, nil, zerolog.Nop())
+	NewRunner(manager, zerolog.Nop())
+
+	process, err := manager.Create(Spec{Connector: "shell"}, shellConnector{script: "echo started; exec sleep 5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 첫 출력이 나온 뒤 (실행 중) 중지
+	deadline := time.Now().Add(5 * time.Second)
+	for len(process.GetEvents()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("process produced no output")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := manager.Stop(process.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	waitDone(t, process)
+	result := process.GetResult()
+	if result == nil || result.Reason != ReasonUserStop {
+		t.Fatalf("result = %+v, want reason %s", result, ReasonUserStop)
+	}
+
+	// 마지막 이벤트는 종료 사유를 담은 done 이벤트
+	events := process.GetEvents()
+	last := events[len(events)-1]
+	var done struct {
+		Status string
+		Result Result
+	}
+	if err := json.Unmarshal(last.Data, &done); err != nil {
+		t.Fatal(err)
+	}
+	if last.Type != "done" || done.Status != StatusStopped || done.Result.Reason != ReasonUserStop {
+		t.Errorf("last event = %s %s, want done with status stopped and reason user_stop", last.Type, last.Data)
+	}
+}
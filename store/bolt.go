@@ -0,0 +1,319 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	bolt "go.etcd.io/bbolt"
+
+	"cli-runner/pkg/metrics"
+	"cli-runner/runner"
+)
+
+// processesBucket은 프로세스 ID → ProcessRecord JSON을 보관합니다
+var processesBucket = []byte("processes")
+
+// 쓰기 작업 종류
+const (
+	writeSave   = iota // 프로세스 기록 저장
+	writeDelete        // 기록 삭제
+)
+
+// maxBatch는 하나의 트랜잭션에 모으는 최대 쓰기 수입니다
+const maxBatch = 512
+
+// 실패한 트랜잭션의 재시도 (writeRetryDelay부터 두 배씩 늘려가며 maxWriteAttempts번까지 시도)
+const (
+	maxWriteAttempts = 4
+	writeRetryDelay  = 100 * time.Millisecond
+)
+
+var ErrStoreClosed = errors.New("store is closed")
+
+// write는 백그라운드에서 반영할 단일 쓰기 작업입니다
+type write struct {
+	kind int
+	id   string
+	data []byte
+}
+
+// BoltStore는 bbolt 임베디드 데이터베이스에 프로세스 기록을 저장합니다
+// 쓰기는 호출 순서대로 백그라운드 고루틴에서 모아 하나의 트랜잭션으로 반영하므로
+// 상태 변경이 디스크 동기화를 기다리지 않습니다 (조회에는 약간의 지연이 있을 수 있음)
+type BoltStore struct {
+	db     *bolt.DB
+	writes chan write
+	done   chan struct{}
+	closed bool
+	mu     sync.RWMutex
+	logger zerolog.Logger
+
+	writeErr   error // 재시도 후에도 실패한 마지막 쓰기 (이후 쓰기가 성공하면 nil)
+	writeErrMu sync.Mutex
+}
+
+// Open은 경로의 데이터베이스를 열고 (없으면 생성) 백그라운드 쓰기를 시작합니다
+// 다른 서버 인스턴스가 파일을 사용 중이면 잠시 기다린 뒤 에러를 반환합니다
+func Open(path string, logger zerolog.Logger) (*BoltStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(processesBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize store: %w", err)
+	}
+
+	s := &BoltStore{
+		db:     db,
+		writes: make(chan write, 1024),
+		done:   make(chan struct{}),
+		logger: logger.With().Str("component", "store").Logger(),
+	}
+	go s.writeLoop()
+
+	s.logger.Info().
+		Str("path", path).
+		Msg("Process store opened")
+
+	return s, nil
+}
+
+// Save는 프로세스 기록 저장을 예약합니다
+func (s *BoltStore) Save(record runner.ProcessRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.enqueue(write{kind: writeSave, id: record.ID, data: data})
+}
+
+// Delete는 프로세스 기록 삭제를 예약합니다
+func (s *BoltStore) Delete(processID string) error {
+	return s.enqueue(write{kind: writeDelete, id: processID})
+}
+
+// Get은 저장된 프로세스 기록을 반환합니다
+func (s *BoltStore) Get(processID string) (*runner.ProcessRecord, error) {
+	var record *runner.ProcessRecord
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(processesBucket).Get([]byte(processID))
+		if data == nil {
+			return runner.ErrProcessNotFound
+		}
+		record = &runner.ProcessRecord{}
+		return json.Unmarshal(data, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// List는 조건에 맞는 기록을 최근 시작 순으로 반환합니다
+func (s *BoltStore) List(filter runner.ProcessFilter) ([]runner.ProcessRecord, error) {
+	records := []runner.ProcessRecord{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(processesBucket).ForEach(func(_, data []byte) error {
+			var record runner.ProcessRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				return err
+			}
+			if filter.Matches(record) {
+				records = append(records, record)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].StartedAt.After(records[j].StartedAt)
+	})
+
+	if filter.Offset >= len(records) {
+		return []runner.ProcessRecord{}, nil
+	}
+	records = records[filter.Offset:]
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
+
+// Prune은 before 이전에 종료된 기록을 삭제하고 삭제한 수를 반환합니다
+func (s *BoltStore) Prune(before time.Time) (int, error) {
+	removed := 0
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var expired [][]byte
+		err := tx.Bucket(processesBucket).ForEach(func(key, data []byte) error {
+			var record runner.ProcessRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				return err
+			}
+			if !record.IsActive() && record.CompletedAt != nil && record.CompletedAt.Before(before) {
+				expired = append(expired, append([]byte(nil), key...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		processes := tx.Bucket(processesBucket)
+		for _, key := range expired {
+			if err := processes.Delete(key); err != nil {
+				return err
+			}
+		}
+		removed = len(expired)
+		return nil
+	})
+
+	return removed, err
+}
+
+// Close는 예약된 쓰기를 모두 반영한 뒤 데이터베이스를 닫습니다
+func (s *BoltStore) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.writes)
+	s.mu.Unlock()
+
+	<-s.done
+
+	s.logger.Info().Msg("Process store closed")
+	return s.db.Close()
+}
+
+// enqueue는 쓰기 작업을 백그라운드 고루틴에 전달합니다
+func (s *BoltStore) enqueue(w write) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	s.writes <- w
+	return nil
+}
+
+// writeLoop는 대기 중인 쓰기를 모아 트랜잭션 단위로 반영합니다
+func (s *BoltStore) writeLoop() {
+	defer close(s.done)
+
+	for w := range s.writes {
+		batch := []write{w}
+
+	drain:
+		for len(batch) < maxBatch {
+			select {
+			case next, ok := <-s.writes:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, next)
+			default:
+				break drain
+			}
+		}
+
+		s.setWriteErr(s.writeBatch(batch))
+	}
+}
+
+// writeBatch는 쓰기 작업들을 하나의 트랜잭션으로 반영합니다
+// 디스크 가득 참과 같은 일시적인 실패에 대비해 간격을 늘려가며 재시도하며,
+// 그동안 이후 쓰기는 채널에서 기다리므로 반영 순서는 바뀌지 않습니다
+func (s *BoltStore) writeBatch(batch []write) error {
+	delay := writeRetryDelay
+
+	var err error
+	for attempt := 1; attempt <= maxWriteAttempts; attempt++ {
+		err = s.db.Update(func(tx *bolt.Tx) error {
+			return applyBatch(tx, batch)
+		})
+		if err == nil {
+			return nil
+		}
+		if attempt == maxWriteAttempts {
+			break
+		}
+
+		s.logger.Warn().
+			Err(err).
+			Int("writes", len(batch)).
+			Int("attempt", attempt).
+			Dur("retryIn", delay).
+			Msg("Failed to write to process store, retrying")
+		time.Sleep(delay)
+		delay *= 2
+	}
+
+	s.logger.Error().
+		Err(err).
+		Int("writes", len(batch)).
+		Msg("Failed to write to process store, dropping writes")
+	metrics.StoreWriteErrors.Add(float64(len(batch)))
+	return err
+}
+
+// setWriteErr는 마지막 쓰기 결과를 기록합니다
+func (s *BoltStore) setWriteErr(err error) {
+	s.writeErrMu.Lock()
+	defer s.writeErrMu.Unlock()
+	s.writeErr = err
+}
+
+// Err는 재시도 후에도 실패한 마지막 쓰기의 에러를 반환합니다 (이후 쓰기가 성공했으면 nil)
+func (s *BoltStore) Err() error {
+	s.writeErrMu.Lock()
+	defer s.writeErrMu.Unlock()
+	return s.writeErr
+}
+
+// applyBatch는 쓰기 작업들을 순서대로 트랜잭션에 반영합니다
+func applyBatch(tx *bolt.Tx, batch []write) error {
+	processes := tx.Bucket(processesBucket)
+
+	for _, w := range batch {
+		var err error
+		switch w.kind {
+		case writeSave:
+			err = processes.Put([]byte(w.id), w.data)
+		case writeDelete:
+			err = processes.Delete([]byte(w.id))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	bolt "go.etcd.io/bbolt"
+
+	"cli-runner/config"
+	"cli-runner/runner"
+)
+
+// openTestStore는 임시 디렉토리에 저장소를 엽니다
+func openTestStore(t *testing.T, path string) *BoltStore {
+	t.Helper()
+	s, err := Open(path, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// testRecord는 start 시각에 시작한 기록을 생성합니다 (completed가 있으면 그 시각에 종료)
+func testRecord(id, status string, start time.Time, completed *time.Time) runner.ProcessRecord {
+	return runner.ProcessRecord{
+		ID:          id,
+		Connector:   "claude",
+		Prompt:      "prompt " + id,
+		Priority:    runner.PriorityInteractive,
+		Status:      status,
+		StartedAt:   start,
+		CompletedAt: completed,
+	}
+}
+
+// recordIDs는 기록의 ID 목록을 반환합니다
+func recordIDs(records []runner.ProcessRecord) []string {
+	var ids []string
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+	return ids
+}
+
+// 저장한 기록은 저장소를 닫았다가 다시 열어도 그대로 조회됨
+func TestBoltStoreReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runner.db")
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	completed := start.Add(time.Minute)
+
+	s := openTestStore(t, path)
+	saved := testRecord("p1", runner.StatusCompleted, start, &completed)
+	saved.Tenant = "team"
+	saved.Result = &runner.Result{ExitCode: 0, Output: json.RawMessage(`{"text":"done"}`)}
+	if err := s.Save(testRecord("p1", runner.StatusRunning, start, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(testRecord("p2", runner.StatusRunning, start, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete("p2"); err != nil {
+		t.Fatal(err)
+	}
+	// Close는 예약된 쓰기를 모두 반영한 뒤 닫음
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(saved); !errors.Is(err, ErrStoreClosed) {
+		t.Errorf("Save after Close error = %v, want %v", err, ErrStoreClosed)
+	}
+
+	reopened := openTestStore(t, path)
+	defer reopened.Close()
+
+	record, err := reopened.Get("p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Status != runner.StatusCompleted || record.Tenant != "team" ||
+		record.Result == nil || string(record.Result.Output) != `{"text":"done"}` ||
+		record.CompletedAt == nil || !record.CompletedAt.Equal(completed) {
+		t.Errorf("restored record = %+v, want the last saved completed record", record)
+	}
+	if _, err := reopened.Get("p2"); !errors.Is(err, runner.ErrProcessNotFound) {
+		t.Errorf("Get(p2) error = %v, want %v", err, runner.ErrProcessNotFound)
+	}
+}
+
+func TestBoltStoreList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runner.db")
+	s := openTestStore(t, path)
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	records := []runner.ProcessRecord{
+		testRecord("a", runner.StatusCompleted, start, nil),
+		testRecord("b", runner.StatusRunning, start.Add(time.Minute), nil),
+		testRecord("c", runner.StatusFailed, start.Add(2*time.Minute), nil),
+		testRecord("d", runner.StatusCompleted, start.Add(3*time.Minute), nil),
+	}
+	records[1].Connector = "gemini"
+	for _, record := range records {
+		if err := s.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 쓰기는 백그라운드에서 반영되므로 닫았다가 다시 열어 조회
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s = openTestStore(t, path)
+	defer s.Close()
+
+	tests := []struct {
+		name   string
+		filter runner.ProcessFilter
+		want   []string
+	}{
+		{name: "all, most recent first", want: []string{"d", "c", "b", "a"}},
+		{name: "by connector", filter: runner.ProcessFilter{Connector: "gemini"}, want: []string{"b"}},
+		{name: "by status", filter: runner.ProcessFilter{Statuses: []string{runner.StatusCompleted, runner.StatusFailed}}, want: []string{"d", "c", "a"}},
+		{name: "paged", filter: runner.ProcessFilter{Offset: 1, Limit: 2}, want: []string{"c", "b"}},
+		{name: "offset past the end", filter: runner.ProcessFilter{Offset: 4}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.List(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ids := recordIDs(got); !slices.Equal(ids, tt.want) {
+				t.Errorf("List = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoltStorePrune(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runner.db")
+	s := openTestStore(t, path)
+	now := time.Now()
+	old := now.Add(-2 * time.Hour)
+	recent := now.Add(-time.Minute)
+
+	for _, record := range []runner.ProcessRecord{
+		testRecord("old", runner.StatusCompleted, old, &old),
+		testRecord("recent", runner.StatusCompleted, recent, &recent),
+		// 종료 시각이 오래되었어도 실행 중으로 기록된 프로세스는 삭제하지 않음
+		testRecord("active", runner.StatusRunning, old, &old),
+	} {
+		if err := s.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s = openTestStore(t, path)
+	defer s.Close()
+
+	removed, err := s.Prune(now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("removed = %d, want 1", removed)
+	}
+	records, err := s.List(runner.ProcessFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := recordIDs(records); !slices.Equal(ids, []string{"recent", "active"}) {
+		t.Errorf("remaining = %v, want [recent active]", ids)
+	}
+}
+
+// 재시작 전에 종료되지 않은 기록은 interrupted로 복구됨
+func TestManagerRecover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runner.db")
+	start := time.Now().Add(-time.Minute)
+	completed := start.Add(time.Second)
+
+	s := openTestStore(t, path)
+	for _, record := range []runner.ProcessRecord{
+		testRecord("queued", runner.StatusQueued, start, nil),
+		testRecord("running", runner.StatusRunning, start, nil),
+		testRecord("done", runner.StatusCompleted, start, &completed),
+	} {
+		if err := s.Save(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s = openTestStore(t, path)
+	manager := runner.NewManager(&config.Config{}, s, nil, zerolog.Nop())
+	if err := manager.Recover(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s = openTestStore(t, path)
+	defer s.Close()
+
+	for id, want := range map[string]string{
+		"queued":  runner.StatusInterrupted,
+		"running": runner.StatusInterrupted,
+		"done":    runner.StatusCompleted,
+	} {
+		record, err := s.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.Status != want {
+			t.Errorf("%s: status = %s, want %s", id, record.Status, want)
+		}
+		if want == runner.StatusInterrupted && (record.CompletedAt == nil || record.Result == nil || record.Result.ExitCode != -1) {
+			t.Errorf("%s: interrupted record = %+v, want completedAt and exit code -1", id, record)
+		}
+	}
+}
+
+// 재시도 후에도 실패한 쓰기는 Err로 알림
+func TestBoltStoreWriteError(t *testing.T) {
+	s := openTestStore(t, filepath.Join(t.TempDir(), "runner.db"))
+
+	// 쓰기 고루틴이 사용하는 데이터베이스를 먼저 닫아 모든 트랜잭션이 실패하게 함
+	if err := s.db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(testRecord("p1", runner.StatusRunning, time.Now(), nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for s.Err() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Err() = nil after a failed write")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !errors.Is(s.Err(), bolt.ErrDatabaseNotOpen) {
+		t.Errorf("Err() = %v, want %v", s.Err(), bolt.ErrDatabaseNotOpen)
+	}
+}
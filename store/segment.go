@@ -0,0 +1,508 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"cli-runner/runner"
+)
+
+// segmentExt는 세그먼트 파일 확장자입니다 (한 줄에 Event JSON 하나)
+const segmentExt = ".ndjson"
+
+// defaultSegmentSize는 설정이 없을 때 사용하는 세그먼트 최대 크기입니다
+const defaultSegmentSize = 8 << 20
+
+var ErrInvalidProcessID = errors.New("invalid process id")
+
+// SegmentLog는 프로세스별 이벤트를 NDJSON 세그먼트 파일에 추가 기록합니다
+// 디렉토리 구조는 <dir>/<processID>/<첫 offset 20자리>.ndjson이며,
+// 세그먼트가 segmentSize를 넘으면 다음 offset으로 시작하는 새 파일에 기록합니다
+type SegmentLog struct {
+	dir         string
+	segmentSize int64
+	writers     map[string]*segmentWriter // 이벤트를 기록 중인 프로세스의 마지막 세그먼트
+	mu          sync.Mutex
+	logger      zerolog.Logger
+}
+
+// segmentWriter는 프로세스의 마지막 세그먼트 파일에 대한 쓰기 상태입니다
+type segmentWriter struct {
+	file  *os.File
+	start int   // 세그먼트의 첫 offset
+	count int   // 세그먼트에 기록된 이벤트 수
+	size  int64 // 세그먼트 크기 (바이트)
+}
+
+// segment는 디스크에 있는 세그먼트 파일입니다
+type segment struct {
+	start int
+	path  string
+}
+
+// OpenSegmentLog는 디렉토리를 준비하고 세그먼트 이벤트 로그를 생성합니다
+func OpenSegmentLog(dir string, segmentSize int64, logger zerolog.Logger) (*SegmentLog, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create event log directory: %w", err)
+	}
+
+	if segmentSize <= 0 {
+		segmentSize = defaultSegmentSize
+	}
+
+	l := &SegmentLog{
+		dir:         dir,
+		segmentSize: segmentSize,
+		writers:     make(map[string]*segmentWriter),
+		logger:      logger.With().Str("component", "eventlog").Logger(),
+	}
+
+	l.logger.Info().
+		Str("dir", dir).
+		Int64("segmentSize", segmentSize).
+		Msg("Event log opened")
+
+	return l, nil
+}
+
+// Append는 이벤트를 프로세스의 마지막 세그먼트에 추가합니다
+func (l *SegmentLog) Append(processID string, event runner.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.append(processID, data); err != nil {
+		l.logger.Error().
+			Err(err).
+			Str("processId", processID).
+			Msg("Failed to append event")
+		return err
+	}
+	return nil
+}
+
+// append는 필요하면 세그먼트를 교체한 뒤 한 줄을 기록합니다 (l.mu를 보유한 상태에서 호출)
+func (l *SegmentLog) append(processID string, line []byte) error {
+	w, err := l.writer(processID)
+	if err != nil {
+		return err
+	}
+
+	if w.size > 0 && w.size+int64(len(line)) > l.segmentSize {
+		if err := l.rotate(processID, w); err != nil {
+			return err
+		}
+	}
+
+	n, err := w.file.Write(line)
+	w.size += int64(n)
+	if err != nil {
+		return err
+	}
+	w.count++
+	return nil
+}
+
+// writer는 프로세스의 쓰기 상태를 반환하며, 없으면 마지막 세그먼트를 열거나 새로 만듭니다
+func (l *SegmentLog) writer(processID string) (*segmentWriter, error) {
+	if w, ok := l.writers[processID]; ok {
+		return w, nil
+	}
+
+	dir, err := l.processDir(processID)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	segments, err := l.segments(processID)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &segmentWriter{}
+	path := segmentPath(dir, 0)
+	if len(segments) > 0 {
+		last := segments[len(segments)-1]
+		count, size, err := countEvents(last.path)
+		if err != nil {
+			return nil, err
+		}
+		w.start, w.count, w.size = last.start, count, size
+		path = last.path
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	// 비정상 종료로 남은 불완전한 마지막 라인은 잘라냄
+	if err := file.Truncate(w.size); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(w.size, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	w.file = file
+	l.writers[processID] = w
+	return w, nil
+}
+
+// rotate는 현재 세그먼트를 닫고 다음 offset으로 시작하는 새 세그먼트를 엽니다
+func (l *SegmentLog) rotate(processID string, w *segmentWriter) error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	dir, err := l.processDir(processID)
+	if err != nil {
+		return err
+	}
+
+	start := w.start + w.count
+	file, err := os.OpenFile(segmentPath(dir, start), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		delete(l.writers, processID)
+		return err
+	}
+
+	w.file, w.start, w.count, w.size = file, start, 0, 0
+	return nil
+}
+
+// Read는 offset부터 최대 limit개(0이면 끝까지)의 이벤트를 순서대로 반환합니다
+func (l *SegmentLog) Read(processID string, offset, limit int) ([]runner.Event, error) {
+	segments, err := l.segments(processID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []runner.Event{}
+	if len(segments) == 0 {
+		return events, nil
+	}
+
+	// offset을 포함하는 세그먼트부터 읽기
+	first := sort.Search(len(segments), func(i int) bool {
+		return segments[i].start > offset
+	}) - 1
+
+	for _, seg := range segments[max(first, 0):] {
+		full, err := readSegment(seg, offset, limit, &events)
+		if err != nil {
+			return nil, err
+		}
+		if full {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// Len은 로그에 기록된 이벤트 수를 반환합니다
+func (l *SegmentLog) Len(processID string) (int, error) {
+	l.mu.Lock()
+	w, ok := l.writers[processID]
+	if ok {
+		count := w.start + w.count
+		l.mu.Unlock()
+		return count, nil
+	}
+	l.mu.Unlock()
+
+	segments, err := l.segments(processID)
+	if err != nil || len(segments) == 0 {
+		return 0, err
+	}
+
+	last := segments[len(segments)-1]
+	count, _, err := countEvents(last.path)
+	if err != nil {
+		return 0, err
+	}
+	return last.start + count, nil
+}
+
+// Finish는 프로세스의 세그먼트 파일을 닫습니다
+func (l *SegmentLog) Finish(processID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.finish(processID)
+}
+
+// finish는 열린 세그먼트 파일을 닫습니다 (l.mu를 보유한 상태에서 호출)
+func (l *SegmentLog) finish(processID string) error {
+	w, ok := l.writers[processID]
+	if !ok {
+		return nil
+	}
+	delete(l.writers, processID)
+	return w.file.Close()
+}
+
+// Delete는 프로세스의 모든 세그먼트를 삭제합니다
+func (l *SegmentLog) Delete(processID string) error {
+	dir, err := l.processDir(processID)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.finish(processID); err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// Prune은 before 이전에 마지막으로 기록된 로그를 삭제한 뒤,
+// 전체 크기가 maxBytes(0이면 제한 없음)를 넘으면 마지막 기록이 오래된 로그부터 삭제합니다
+// keep이 true를 반환하거나 기록 중인 프로세스의 로그는 삭제하지 않습니다
+func (l *SegmentLog) Prune(before time.Time, maxBytes int64, keep func(processID string) bool) (int, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return 0, err
+	}
+
+	type logInfo struct {
+		id      string
+		size    int64
+		modTime time.Time
+	}
+
+	var candidates []logInfo
+	var total int64
+	removed := 0
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		id := entry.Name()
+
+		size, modTime, err := dirUsage(filepath.Join(l.dir, id))
+		if err != nil {
+			return removed, err
+		}
+
+		l.mu.Lock()
+		_, writing := l.writers[id]
+		l.mu.Unlock()
+
+		if writing || keep(id) {
+			total += size
+			continue
+		}
+
+		if !before.IsZero() && modTime.Before(before) {
+			if err := l.Delete(id); err != nil {
+				return removed, err
+			}
+			removed++
+			continue
+		}
+
+		total += size
+		candidates = append(candidates, logInfo{id: id, size: size, modTime: modTime})
+	}
+
+	if maxBytes > 0 && total > maxBytes {
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].modTime.Before(candidates[j].modTime)
+		})
+
+		for _, info := range candidates {
+			if total <= maxBytes {
+				break
+			}
+			if err := l.Delete(info.id); err != nil {
+				return removed, err
+			}
+			total -= info.size
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+// Close는 열린 모든 세그먼트 파일을 닫습니다
+func (l *SegmentLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var errs []error
+	for processID := range l.writers {
+		errs = append(errs, l.finish(processID))
+	}
+
+	l.logger.Info().Msg("Event log closed")
+	return errors.Join(errs...)
+}
+
+// processDir는 프로세스의 세그먼트 디렉토리 경로를 반환합니다
+// 경로 조작을 막기 위해 단일 경로 요소가 아닌 ID는 거부합니다
+func (l *SegmentLog) processDir(processID string) (string, error) {
+	if processID == "" || processID == "." || processID == ".." ||
+		strings.ContainsAny(processID, `/\`) {
+		return "", ErrInvalidProcessID
+	}
+	return filepath.Join(l.dir, processID), nil
+}
+
+// segments는 프로세스의 세그먼트 파일을 시작 offset 순으로 반환합니다
+func (l *SegmentLog) segments(processID string) ([]segment, error) {
+	dir, err := l.processDir(processID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []segment
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		start, err := strconv.Atoi(strings.TrimSuffix(name, segmentExt))
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{start: start, path: filepath.Join(dir, name)})
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].start < segments[j].start
+	})
+	return segments, nil
+}
+
+// readSegment는 세그먼트에서 offset 이후의 이벤트를 events에 추가합니다
+// limit개를 채우면 true를 반환합니다 (기록 중인 불완전한 마지막 라인은 무시)
+func readSegment(seg segment, offset, limit int, events *[]runner.Event) (bool, error) {
+	file, err := os.Open(seg.path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReaderSize(file, 64*1024)
+	index := seg.start
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			if index >= offset {
+				var event runner.Event
+				if err := json.Unmarshal(line, &event); err != nil {
+					return false, fmt.Errorf("corrupt event %d in %s: %w", index, seg.path, err)
+				}
+				if event.Seq == 0 {
+					// 순번이 도입되기 전에 기록된 이벤트
+					event.Seq = index + 1
+				}
+				*events = append(*events, event)
+				if limit > 0 && len(*events) >= limit {
+					return true, nil
+				}
+			}
+			index++
+		}
+
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// countEvents는 세그먼트의 완전한 라인 수와 그 라인들이 차지하는 크기를 반환합니다
+func countEvents(path string) (int, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReaderSize(file, 64*1024)
+	count := 0
+	var size int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			count++
+			size += int64(len(line))
+		}
+
+		if errors.Is(err, io.EOF) {
+			return count, size, nil
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+}
+
+// dirUsage는 디렉토리 안 파일들의 전체 크기와 가장 최근 수정 시각을 반환합니다
+func dirUsage(dir string) (int64, time.Time, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	var size int64
+	var modTime time.Time
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		size += info.Size()
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return size, modTime, nil
+}
+
+// segmentPath는 시작 offset으로 세그먼트 파일 경로를 만듭니다 (이름순 정렬이 offset 순서와 같도록 0으로 채움)
+func segmentPath(dir string, start int) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d%s", start, segmentExt))
+}
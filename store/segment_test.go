@@ -0,0 +1,314 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"cli-runner/runner"
+)
+
+// testEvent는 n에 관계없이 같은 크기로 기록되는 이벤트를 생성합니다 (n은 0~9)
+func testEvent(n int) runner.Event {
+	return runner.Event{
+		Seq:       n + 1,
+		Type:      "stream",
+		Data:      json.RawMessage(fmt.Sprintf(`{"n":%d}`, n)),
+		Timestamp: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// lineSize는 testEvent 한 줄의 크기입니다
+func lineSize(t *testing.T) int64 {
+	t.Helper()
+	data, err := json.Marshal(testEvent(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int64(len(data) + 1)
+}
+
+// openTestLog는 세그먼트마다 이벤트 3개를 기록하는 로그를 엽니다
+func openTestLog(t *testing.T, dir string) *SegmentLog {
+	t.Helper()
+	l, err := OpenSegmentLog(dir, 3*lineSize(t), zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+// appendEvents는 offset from부터 to 전까지의 이벤트를 기록합니다
+func appendEvents(t *testing.T, l *SegmentLog, processID string, from, to int) {
+	t.Helper()
+	for n := from; n < to; n++ {
+		if err := l.Append(processID, testEvent(n)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// eventNumbers는 testEvent로 기록한 이벤트들의 n을 반환합니다
+func eventNumbers(t *testing.T, events []runner.Event) []int {
+	t.Helper()
+	var numbers []int
+	for _, event := range events {
+		var data struct{ N int }
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			t.Fatal(err)
+		}
+		if event.Seq != data.N+1 {
+			t.Errorf("event %d has seq %d", data.N, event.Seq)
+		}
+		numbers = append(numbers, data.N)
+	}
+	return numbers
+}
+
+func TestSegmentLogRollover(t *testing.T) {
+	dir := t.TempDir()
+	l := openTestLog(t, dir)
+	appendEvents(t, l, "p1", 0, 7)
+
+	segments, err := l.segments("p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var starts []int
+	for _, seg := range segments {
+		starts = append(starts, seg.start)
+	}
+	if want := []int{0, 3, 6}; !slices.Equal(starts, want) {
+		t.Fatalf("segment starts = %v, want %v", starts, want)
+	}
+
+	// 닫은 뒤 다시 열면 마지막 세그먼트에 이어서 기록
+	if err := l.Finish("p1"); err != nil {
+		t.Fatal(err)
+	}
+	appendEvents(t, l, "p1", 7, 10)
+
+	if count, err := l.Len("p1"); err != nil || count != 10 {
+		t.Fatalf("Len = %d, %v, want 10", count, err)
+	}
+
+	tests := []struct {
+		name      string
+		processID string // 비어있으면 p1
+		offset    int
+		limit     int
+		want      []int // 반환될 이벤트의 n
+	}{
+		{name: "all", offset: 0, limit: 0, want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{name: "within first segment", offset: 1, limit: 2, want: []int{1, 2}},
+		{name: "across segments", offset: 2, limit: 3, want: []int{2, 3, 4}},
+		{name: "segment boundary", offset: 3, limit: 1, want: []int{3}},
+		{name: "resumed segment", offset: 6, limit: 0, want: []int{6, 7, 8, 9}},
+		{name: "rolled over after reopen", offset: 9, limit: 5, want: []int{9}},
+		{name: "past the end", offset: 10, limit: 0, want: nil},
+		{name: "unknown process", processID: "p2", offset: 0, limit: 0, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processID := tt.processID
+			if processID == "" {
+				processID = "p1"
+			}
+
+			events, err := l.Read(processID, tt.offset, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := eventNumbers(t, events); !slices.Equal(got, tt.want) {
+				t.Errorf("Read(%d, %d) = %v, want %v", tt.offset, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+// 서버 재시작 뒤 새로 연 로그에서도 기존 이벤트를 읽고 마지막 세그먼트에 이어서 기록함
+func TestSegmentLogReopen(t *testing.T) {
+	dir := t.TempDir()
+	l := openTestLog(t, dir)
+	appendEvents(t, l, "p1", 0, 4)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := openTestLog(t, dir)
+	if count, err := reopened.Len("p1"); err != nil || count != 4 {
+		t.Fatalf("Len after reopen = %d, %v, want 4", count, err)
+	}
+
+	appendEvents(t, reopened, "p1", 4, 6)
+	events, err := reopened.Read("p1", 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := eventNumbers(t, events), []int{3, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("events after reopen = %v, want %v", got, want)
+	}
+}
+
+func TestSegmentLogDelete(t *testing.T) {
+	dir := t.TempDir()
+	l := openTestLog(t, dir)
+	appendEvents(t, l, "p1", 0, 5)
+	appendEvents(t, l, "p2", 0, 1)
+
+	// 기록 중인 로그도 파일을 닫고 삭제
+	if err := l.Delete("p1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "p1")); !os.IsNotExist(err) {
+		t.Errorf("p1 directory still exists (stat error: %v)", err)
+	}
+	if count, err := l.Len("p1"); err != nil || count != 0 {
+		t.Errorf("Len after delete = %d, %v, want 0", count, err)
+	}
+	if count, _ := l.Len("p2"); count != 1 {
+		t.Errorf("Len of p2 = %d, want 1", count)
+	}
+
+	// 삭제 뒤 다시 기록하면 offset 0부터 시작
+	appendEvents(t, l, "p1", 0, 1)
+	if count, _ := l.Len("p1"); count != 1 {
+		t.Errorf("Len after rewrite = %d, want 1", count)
+	}
+}
+
+func TestSegmentLogRejectsPathIDs(t *testing.T) {
+	l := openTestLog(t, t.TempDir())
+
+	for _, id := range []string{"", ".", "..", "../p1", `a\b`} {
+		if err := l.Append(id, testEvent(0)); !errors.Is(err, ErrInvalidProcessID) {
+			t.Errorf("Append(%q) error = %v, want %v", id, err, ErrInvalidProcessID)
+		}
+		if err := l.Delete(id); !errors.Is(err, ErrInvalidProcessID) {
+			t.Errorf("Delete(%q) error = %v, want %v", id, err, ErrInvalidProcessID)
+		}
+	}
+}
+
+func TestSegmentLogPrune(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		maxAge   time.Duration // 0이면 나이로 삭제하지 않음
+		maxBytes int64         // 이벤트 줄 수 단위 (0이면 제한 없음)
+		keep     []string
+		want     []string // 남아있어야 하는 프로세스
+		removed  int
+	}{
+		{
+			name:    "by age",
+			maxAge:  time.Hour,
+			want:    []string{"new", "writing"},
+			removed: 2,
+		},
+		{
+			name:    "by age keeps protected logs",
+			maxAge:  time.Hour,
+			keep:    []string{"oldest"},
+			want:    []string{"new", "oldest", "writing"},
+			removed: 1,
+		},
+		{
+			// old와 oldest가 나이로 삭제된 뒤 new(2줄)와 writing(1줄)이 3줄 제한 안에 들어옴
+			name:     "by size after age",
+			maxAge:   time.Hour,
+			maxBytes: 3,
+			want:     []string{"new", "writing"},
+			removed:  2,
+		},
+		{
+			// 나이 제한 없이 크기만 넘으면 오래된 로그부터 삭제
+			name:     "by size only",
+			maxBytes: 4,
+			want:     []string{"new", "old", "writing"},
+			removed:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			l := openTestLog(t, dir)
+
+			// 프로세스마다 이벤트 수와 마지막 기록 시각
+			logs := []struct {
+				id      string
+				events  int
+				modTime time.Time
+			}{
+				{id: "oldest", events: 2, modTime: now.Add(-3 * time.Hour)},
+				{id: "old", events: 1, modTime: now.Add(-2 * time.Hour)},
+				{id: "new", events: 2, modTime: now},
+			}
+			for _, log := range logs {
+				appendEvents(t, l, log.id, 0, log.events)
+				if err := l.Finish(log.id); err != nil {
+					t.Fatal(err)
+				}
+				setModTime(t, filepath.Join(dir, log.id), log.modTime)
+			}
+
+			// 기록 중인 로그는 오래되어도 삭제하지 않음
+			appendEvents(t, l, "writing", 0, 1)
+			setModTime(t, filepath.Join(dir, "writing"), now.Add(-4*time.Hour))
+
+			var before time.Time
+			if tt.maxAge > 0 {
+				before = now.Add(-tt.maxAge)
+			}
+			maxBytes := tt.maxBytes * lineSize(t)
+
+			removed, err := l.Prune(before, maxBytes, func(processID string) bool {
+				return slices.Contains(tt.keep, processID)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if removed != tt.removed {
+				t.Errorf("removed = %d, want %d", removed, tt.removed)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, entry := range entries {
+				got = append(got, entry.Name())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("remaining logs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// setModTime은 프로세스 디렉토리 안 세그먼트 파일의 수정 시각을 바꿉니다
+func setModTime(t *testing.T, dir string, modTime time.Time) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if err := os.Chtimes(filepath.Join(dir, entry.Name()), modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}